@@ -24,20 +24,26 @@ import (
 var (
 	output    = flag.String("output", "", "output file name; default srcdir/accessor.go")
 	buildTags = flag.String("tags", "", "comma-separated list of build tags to apply")
+	exported  = flag.Bool("exported", false, "export all accessors, including those requested with lowercase get and set")
+	options   = flag.Bool("options", false, "also generate a constructor with functional options for each struct")
 )
 
 func main() {
 	log.SetFlags(0)
 	log.SetPrefix("accessor: ")
 
-	// Inspect arguments
 	flag.Parse()
+	run(flag.Args())
+}
+
+func run(args []string) {
+	// Inspect arguments
 	var tags []string
 	if len(*buildTags) > 0 {
 		tags = strings.Split(*buildTags, ",")
 	}
 
-	args := flag.Args() // one directory or a list of files
+	// args is one directory or a list of files
 	if len(args) == 0 {
 		args = []string{"."} // default: current directory
 	}
@@ -51,6 +57,14 @@ func main() {
 		}
 		outputDir = filepath.Dir(args[0])
 	}
+	outputName := *output
+	if outputName == "" {
+		outputName = "accessor.go"
+	}
+	outputFile, err := filepath.Abs(filepath.Join(outputDir, outputName))
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	// Parse
 	cfg := &packages.Config{
@@ -68,8 +82,10 @@ func main() {
 	// Generate
 	g := &generator{
 		pkg:           pkgs[0],
+		outputFile:    outputFile,
 		pkgNameCounts: map[string]int{},
 		pkgNames:      map[string]string{},
+		funcNames:     map[string]bool{},
 	}
 	g.generate()
 
@@ -77,11 +93,6 @@ func main() {
 	src := g.format()
 
 	// Write to file
-	outputName := *output
-	if outputName == "" {
-		outputName = "accessor.go"
-	}
-	outputFile := filepath.Join(outputDir, outputName)
 	if err := os.WriteFile(outputFile, src, 0644); err != nil {
 		log.Fatalf("writing outputFile: %s", err)
 	}
@@ -101,8 +112,21 @@ type generator struct {
 	accessors bytes.Buffer // accessors part of the output
 	pkg       *packages.Package
 
+	// outputFile is the absolute path of the output. Its current contents,
+	// if any, are left out, since they are being replaced.
+	outputFile string
+
 	pkgNameCounts map[string]int    // key=name, value=count
 	pkgNames      map[string]string // key=path, value=name (with count)
+
+	optionFields []optionField   // tagged fields of the current struct, for -options
+	funcNames    map[string]bool // generated top-level function names
+}
+
+type optionField struct {
+	method string // accessor name without the get/set prefix
+	typ    string
+	field  string
 }
 
 func (g *generator) printf(format string, args ...any) {
@@ -116,6 +140,9 @@ func (g *generator) generate() {
 	// Loop files
 	files := g.pkg.Syntax
 	for _, file := range files {
+		if g.inOutput(file.Pos()) {
+			continue
+		}
 		// Loop top-level type declarations
 		for _, decl := range file.Decls {
 			gd, ok := decl.(*ast.GenDecl)
@@ -129,6 +156,8 @@ func (g *generator) generate() {
 				if !ok {
 					continue
 				}
+				dirty := &dirtyBits{typ: g.fieldType(st, "dirty")}
+				g.optionFields = g.optionFields[:0]
 				// Loop struct fields
 				for _, field := range st.Fields.List {
 					// Loop field names
@@ -140,9 +169,14 @@ func (g *generator) generate() {
 							name:               name,
 							typ:                field.Type,
 							tag:                field.Tag,
+							dirty:              dirty,
 						})
 					}
 				}
+				// Print options
+				if *options && len(g.optionFields) > 0 {
+					g.printOptions(tspec)
+				}
 			}
 		}
 	}
@@ -172,11 +206,11 @@ func (g *generator) generate() {
 
 	g.printf("import (\n")
 	for _, stmt := range stdImports {
-		g.printf(stmt)
+		g.printf("%s", stmt)
 	}
 	g.printf("\n")
 	for _, stmt := range extImports {
-		g.printf(stmt)
+		g.printf("%s", stmt)
 	}
 	g.printf(")\n")
 }
@@ -187,6 +221,27 @@ type structField struct {
 	name               *ast.Ident
 	typ                ast.Expr
 	tag                *ast.BasicLit
+	dirty              *dirtyBits // shared by the fields of a struct
+}
+
+// dirtyBits assigns bits of a struct's "dirty uint64" field to the fields
+// tagged with "dirty", in declaration order.
+type dirtyBits struct {
+	typ  types.Type // type of the dirty field, or nil if there is none
+	next int        // next bit index to assign
+}
+
+// fieldType returns the type of the named field of st, or nil if st has no
+// such field.
+func (g *generator) fieldType(st *ast.StructType, name string) types.Type {
+	for _, field := range st.Fields.List {
+		for _, n := range field.Names {
+			if n.Name == name {
+				return g.pkg.TypesInfo.TypeOf(field.Type)
+			}
+		}
+	}
+	return nil
 }
 
 func (g *generator) printAccessors(f *structField) {
@@ -220,9 +275,12 @@ func (g *generator) printAccessors(f *structField) {
 
 	// Build method name
 	method := ""
+	dirty := false
 	for _, arg := range args {
 		switch arg {
 		case "get", "set", "Get", "Set":
+		case "dirty":
+			dirty = true
 		default:
 			if method != "" {
 				log.Fatal("error: cannot define multiple accessor names within a tag")
@@ -245,16 +303,44 @@ func (g *generator) printAccessors(f *structField) {
 	// Build field name
 	field := g.nodeString(f.name)
 
+	// Assign dirty bit
+	bit := -1
+	if dirty {
+		if f.dirty.typ == nil {
+			log.Fatalf("error: %s.%s: dirty option requires a dirty field", recv, field)
+		}
+		if !types.Identical(f.dirty.typ.Underlying(), types.Typ[types.Uint64]) {
+			log.Fatalf("error: %s.dirty: dirty field must be a uint64, not %s", recv, f.dirty.typ)
+		}
+		if f.dirty.next >= 64 {
+			log.Fatalf("error: %s: more than 64 dirty fields", recv)
+		}
+		bit = f.dirty.next
+		f.dirty.next++
+	}
+
+	// Collect options
+	if *options {
+		g.optionFields = append(g.optionFields, optionField{method, typ, field})
+	}
+
 	// Print comments
 	g.printf("// %s.%s: %s\n", recv, field, tag)
 
 	// Print accessors
+	// Lowercase get and set give unexported accessors and Get and Set
+	// exported ones, whatever the case of the field, unless -exported forces
+	// them all exported.
 	for _, arg := range args {
+		prefix := arg
+		if *exported {
+			prefix = strings.ToUpper(arg[:1]) + arg[1:]
+		}
 		switch arg {
 		case "get", "Get":
-			g.printGetter(recv, arg+method, typ, field)
+			g.printGetter(recv, prefix+method, typ, field)
 		case "set", "Set":
-			g.printSetter(recv, arg+method, typ, field)
+			g.printSetter(recv, prefix+method, typ, field, bit)
 		}
 	}
 }
@@ -263,8 +349,85 @@ func (g *generator) printGetter(recv, method, typ, field string) {
 	g.printf("func (x *%s) %s() %s { return x.%s }\n", recv, method, typ, field)
 }
 
-func (g *generator) printSetter(recv, method, typ, field string) {
-	g.printf("func (x *%s) %s(value %s) { x.%s = value }\n", recv, method, typ, field)
+// printSetter prints a setter. If bit is not negative, the setter also sets
+// that bit of the dirty field.
+func (g *generator) printSetter(recv, method, typ, field string, bit int) {
+	if bit < 0 {
+		g.printf("func (x *%s) %s(value %s) { x.%s = value }\n", recv, method, typ, field)
+		return
+	}
+	g.printf("func (x *%s) %s(value %s) { x.dirty |= 1 << %d; x.%s = value }\n", recv, method, typ, bit, field)
+}
+
+// printOptions prints an option type, a constructor and an option function
+// per tagged field for the struct:
+//
+//	type TOption func(*T)
+//	func NewT(opts ...TOption) *T
+//	func WithField(value F) TOption
+//
+// An option function whose name is already taken in the package is named
+// WithTField instead.
+func (g *generator) printOptions(tspec *ast.TypeSpec) {
+	name := tspec.Name.Name
+
+	// Build type parameters for declarations and instantiations
+	tparams, targs := "", ""
+	if tspec.TypeParams != nil {
+		decls, names := []string{}, []string{}
+		for _, param := range tspec.TypeParams.List {
+			pnames := []string{}
+			for _, n := range param.Names {
+				pnames = append(pnames, n.Name)
+			}
+			decls = append(decls, strings.Join(pnames, ", ")+" "+g.nodeString(param.Type))
+			names = append(names, pnames...)
+		}
+		tparams = "[" + strings.Join(decls, ", ") + "]"
+		targs = "[" + strings.Join(names, ", ") + "]"
+	}
+	typ := name + targs
+	opt := name + "Option" + targs
+	for _, n := range []string{name + "Option", "New" + name} {
+		if g.declared(n) {
+			log.Fatalf("error: %s: %s already exists", name, n)
+		}
+	}
+
+	// Print option type and constructor
+	g.printf("// %sOption configures a %s created by New%s.\n", name, name, name)
+	g.printf("type %sOption%s func(*%s)\n", name, tparams, typ)
+	g.printf("func New%s%s(opts ...%s) *%s {\n", name, tparams, opt, typ)
+	g.printf("x := &%s{}\n", typ)
+	g.printf("for _, opt := range opts { opt(x) }\n")
+	g.printf("return x\n")
+	g.printf("}\n")
+
+	// Print option functions
+	for _, f := range g.optionFields {
+		fn := "With" + f.method
+		if g.funcNames[fn] || g.declared(fn) {
+			fn = "With" + name + f.method
+		}
+		if g.funcNames[fn] || g.declared(fn) {
+			log.Fatalf("error: %s: option function %s already exists", name, fn)
+		}
+		g.funcNames[fn] = true
+		g.printf("func %s%s(value %s) %s { return func(x *%s) { x.%s = value } }\n", fn, tparams, f.typ, opt, typ, f.field)
+	}
+}
+
+// declared reports whether name is declared at package level outside the
+// output file.
+func (g *generator) declared(name string) bool {
+	obj := g.pkg.Types.Scope().Lookup(name)
+	return obj != nil && !g.inOutput(obj.Pos())
+}
+
+// inOutput reports whether pos is in the output file.
+func (g *generator) inOutput(pos token.Pos) bool {
+	name, err := filepath.Abs(g.pkg.Fset.Position(pos).Filename)
+	return err == nil && name == g.outputFile
 }
 
 func (g *generator) nodeString(node ast.Node) string {
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	// Run as the generator for generateError.
+	if dir := os.Getenv("ACCESSOR_TEST_DIR"); dir != "" {
+		log.SetFlags(0)
+		log.SetPrefix("accessor: ")
+		flag.Parse()
+		if err := os.Chdir(dir); err != nil {
+			log.Fatal(err)
+		}
+		run(flag.Args())
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// writePackage writes src as the only file of a new module in a temporary
+// directory and returns the directory.
+func writePackage(t *testing.T, src string) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/p\n\ngo 1.24\n",
+		"p.go":   src,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// generate runs the generator in dir and returns the generated file.
+func generate(t *testing.T, dir string) string {
+	t.Helper()
+	t.Chdir(dir)
+	run(nil)
+	b, err := os.ReadFile("accessor.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+// generateError runs the generator with flags on src in a separate process,
+// expecting it to fail, and returns its output.
+func generateError(t *testing.T, src string, flags ...string) string {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], flags...)
+	cmd.Env = append(os.Environ(), "ACCESSOR_TEST_DIR="+writePackage(t, src))
+	out, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Fatalf("generator succeeded:\n%s", out)
+	}
+	return string(out)
+}
+
+// setFlag sets a boolean flag for the duration of the test.
+func setFlag(t *testing.T, f *bool, v bool) {
+	old := *f
+	*f = v
+	t.Cleanup(func() { *f = old })
+}
+
+// hasLine reports whether out contains line, ignoring differences in
+// spacing.
+func hasLine(out, line string) bool {
+	want := strings.Join(strings.Fields(line), " ")
+	for _, l := range strings.Split(out, "\n") {
+		if strings.Join(strings.Fields(l), " ") == want {
+			return true
+		}
+	}
+	return false
+}
+
+const mixedSrc = `package p
+
+type T struct {
+	Name  string ` + "`accessor:\"get,set\"`" + `
+	Score int    ` + "`accessor:\"Get,Set\"`" + `
+	hp    int    ` + "`accessor:\"get,Set\"`" + `
+	id    int    ` + "`accessor:\"Get\"`" + `
+}
+`
+
+func TestVisibility(t *testing.T) {
+	out := generate(t, writePackage(t, mixedSrc))
+	for _, line := range []string{
+		"func (x *T) getName() string { return x.Name }",
+		"func (x *T) setName(value string) { x.Name = value }",
+		"func (x *T) GetScore() int { return x.Score }",
+		"func (x *T) SetScore(value int) { x.Score = value }",
+		"func (x *T) getHp() int { return x.hp }",
+		"func (x *T) SetHp(value int) { x.hp = value }",
+		"func (x *T) GetId() int { return x.id }",
+	} {
+		if !hasLine(out, line) {
+			t.Errorf("missing %q in:\n%s", line, out)
+		}
+	}
+}
+
+func TestVisibilityExported(t *testing.T) {
+	setFlag(t, exported, true)
+	out := generate(t, writePackage(t, mixedSrc))
+	for _, line := range []string{
+		"func (x *T) GetName() string { return x.Name }",
+		"func (x *T) SetName(value string) { x.Name = value }",
+		"func (x *T) GetScore() int { return x.Score }",
+		"func (x *T) GetHp() int { return x.hp }",
+		"func (x *T) SetHp(value int) { x.hp = value }",
+		"func (x *T) GetId() int { return x.id }",
+	} {
+		if !hasLine(out, line) {
+			t.Errorf("missing %q in:\n%s", line, out)
+		}
+	}
+}
+
+const optionsSrc = `package p
+
+type T struct {
+	name  string ` + "`accessor:\"get\"`" + `
+	Score int    ` + "`accessor:\"Get\"`" + `
+}
+
+type Box[V any] struct {
+	value V ` + "`accessor:\"get\"`" + `
+}
+
+func WithScore() {}
+`
+
+func TestOptions(t *testing.T) {
+	setFlag(t, options, true)
+	dir := writePackage(t, optionsSrc)
+	out := generate(t, dir)
+	for _, line := range []string{
+		"type TOption func(*T)",
+		"func NewT(opts ...TOption) *T {",
+		"func WithName(value string) TOption { return func(x *T) { x.name = value } }",
+		"func WithTScore(value int) TOption { return func(x *T) { x.Score = value } }",
+		"type BoxOption[V any] func(*Box[V])",
+		"func NewBox[V any](opts ...BoxOption[V]) *Box[V] {",
+		"func WithValue[V any](value V) BoxOption[V] { return func(x *Box[V]) { x.value = value } }",
+	} {
+		if !hasLine(out, line) {
+			t.Errorf("missing %q in:\n%s", line, out)
+		}
+	}
+
+	// Check that the options set the fields.
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+	test := `package p
+
+import "testing"
+
+func TestNew(t *testing.T) {
+	if x := NewT(WithName("x"), WithTScore(1)); x.name != "x" || x.Score != 1 {
+		t.Errorf("NewT: got %+v", *x)
+	}
+	if x := NewBox(WithValue(1.5)); x.value != 1.5 {
+		t.Errorf("NewBox: got %+v", *x)
+	}
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "p_test.go"), []byte(test), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command(gobin, "test", ".")
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("go test: %v\n%s", err, out)
+	}
+}
+
+func TestOptionsRerun(t *testing.T) {
+	setFlag(t, options, true)
+	dir := writePackage(t, optionsSrc)
+	first := generate(t, dir)
+	second := generate(t, dir)
+	if first != second {
+		t.Errorf("second run differs:\n%s\nfirst run:\n%s", second, first)
+	}
+}
+
+func TestOptionsExisting(t *testing.T) {
+	out := generateError(t, `package p
+
+type T struct {
+	a int `+"`accessor:\"get\"`"+`
+}
+
+type TOption int
+`, "-options")
+	if !strings.Contains(out, "TOption already exists") {
+		t.Errorf("unexpected output: %s", out)
+	}
+}
+
+func TestDirty(t *testing.T) {
+	out := generate(t, writePackage(t, `package p
+
+type T struct {
+	a     int    `+"`accessor:\"set,dirty\"`"+`
+	b     string `+"`accessor:\"set\"`"+`
+	c     bool   `+"`accessor:\"set,dirty\"`"+`
+	dirty uint64
+}
+`))
+	for _, line := range []string{
+		"func (x *T) setA(value int) { x.dirty |= 1 << 0; x.a = value }",
+		"func (x *T) setB(value string) { x.b = value }",
+		"func (x *T) setC(value bool) { x.dirty |= 1 << 1; x.c = value }",
+	} {
+		if !hasLine(out, line) {
+			t.Errorf("missing %q in:\n%s", line, out)
+		}
+	}
+}
+
+func TestDirtyFieldType(t *testing.T) {
+	for _, typ := range []string{"bool", "uint32"} {
+		out := generateError(t, `package p
+
+type T struct {
+	a     int `+"`accessor:\"set,dirty\"`"+`
+	dirty `+typ+`
+}
+`)
+		if !strings.Contains(out, "dirty field must be a uint64") {
+			t.Errorf("dirty %s: unexpected output: %s", typ, out)
+		}
+	}
+}
+
+func TestDirtyFieldMissing(t *testing.T) {
+	out := generateError(t, `package p
+
+type T struct {
+	a int `+"`accessor:\"set,dirty\"`"+`
+}
+`)
+	if !strings.Contains(out, "dirty option requires a dirty field") {
+		t.Errorf("unexpected output: %s", out)
+	}
+}
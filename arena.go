@@ -0,0 +1,78 @@
+package ei
+
+import "iter"
+
+// Handle refers to an entity in an Arena. Unlike a slice index, a handle
+// never refers to a different entity once its own has been swept. The zero
+// Handle is never valid.
+type Handle struct {
+	index      uint32
+	generation uint32
+}
+
+// Index returns the slot index of h. Indices are reused, so an index alone
+// does not identify an entity over time.
+func (h Handle) Index() uint32 { return h.index }
+
+// Generation returns the generation of h, which changes each time its index
+// is reused.
+func (h Handle) Generation() uint32 { return h.generation }
+
+// Arena stores entities in reusable slots addressed by handles.
+type Arena[E Interface] struct {
+	slots []E // indexed by Handle.index
+	ids   IDAllocator
+}
+
+// Insert stores e and returns its handle.
+func (a *Arena[E]) Insert(e E) Handle {
+	h := a.ids.Alloc()
+	if int(h.index) == len(a.slots) {
+		a.slots = append(a.slots, e)
+	} else {
+		a.slots[h.index] = e
+	}
+	return h
+}
+
+// Get returns the entity referred to by h. It reports false if the entity
+// is dead or has been swept.
+func (a *Arena[E]) Get(h Handle) (E, bool) {
+	if a.ids.Valid(h) && a.slots[h.index].Alive() {
+		return a.slots[h.index], true
+	}
+	var zero E
+	return zero, false
+}
+
+// Sweep frees the slots of dead entities, invalidating their handles, and
+// returns the number of freed slots.
+func (a *Arena[E]) Sweep() int {
+	n := 0
+	var zero E
+	for i, e := range a.slots {
+		if a.ids.used[i] && !e.Alive() {
+			dispose(e)
+			a.slots[i] = zero
+			a.ids.Free(a.ids.handle(i))
+			n++
+		}
+	}
+	return n
+}
+
+// Len returns the number of stored entities, including dead ones not yet
+// swept.
+func (a *Arena[E]) Len() int { return a.ids.Len() }
+
+// All returns an iterator over the live entities and their handles in slot
+// order.
+func (a *Arena[E]) All() iter.Seq2[Handle, E] {
+	return func(yield func(Handle, E) bool) {
+		for i, e := range a.slots {
+			if a.ids.used[i] && e.Alive() && !yield(a.ids.handle(i), e) {
+				return
+			}
+		}
+	}
+}
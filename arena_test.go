@@ -0,0 +1,41 @@
+package ei
+
+import "testing"
+
+func TestArena(t *testing.T) {
+	var a Arena[*disposed]
+	x, y := &disposed{}, &disposed{}
+	hx, hy := a.Insert(x), a.Insert(y)
+	if hx == (Handle{}) || hx == hy {
+		t.Fatalf("handles %v, %v", hx, hy)
+	}
+	x.Kill()
+	if _, ok := a.Get(hx); ok {
+		t.Error("Get() found a dead entity")
+	}
+	if n := a.Sweep(); n != 1 || x.n != 1 || a.Len() != 1 {
+		t.Errorf("Sweep() = %d, disposed %d times, Len() = %d, want 1, 1, 1", n, x.n, a.Len())
+	}
+
+	z := &disposed{}
+	hz := a.Insert(z)
+	if hz.Index() != hx.Index() || hz.Generation() == hx.Generation() {
+		t.Errorf("new handle %v does not reuse the slot of %v with a new generation", hz, hx)
+	}
+	if _, ok := a.Get(hx); ok {
+		t.Error("stale handle refers to the new entity")
+	}
+	if e, ok := a.Get(hz); !ok || e != z {
+		t.Errorf("Get(hz) = %p, %v, want z, true", e, ok)
+	}
+	n := 0
+	for h, e := range a.All() {
+		if got, _ := a.Get(h); got != e {
+			t.Errorf("All() yielded %v with a mismatched entity", h)
+		}
+		n++
+	}
+	if n != 2 {
+		t.Errorf("All() yielded %d entities, want 2", n)
+	}
+}
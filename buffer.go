@@ -0,0 +1,36 @@
+package ei
+
+// Buffer holds live entities and entities spawned during the current frame.
+// Spawned entities are not visible in Live until Commit, so it is safe to
+// spawn while ranging over Live:
+//
+//	for _, e := range buf.Live() {
+//		e.Update(&buf) // may call buf.Spawn
+//	}
+//	buf.Sweep()
+//	buf.Commit()
+type Buffer[E Interface] struct {
+	live    []E
+	pending []E
+}
+
+// Spawn adds e to the pending entities.
+func (b *Buffer[E]) Spawn(e E) { b.pending = append(b.pending, e) }
+
+// Commit appends the pending entities to the live entities.
+func (b *Buffer[E]) Commit() {
+	b.live = append(b.live, b.pending...)
+	clear(b.pending)
+	b.pending = b.pending[:0]
+}
+
+// Sweep removes dead entities from the live entities and returns the number
+// removed. Pending entities are left alone.
+func (b *Buffer[E]) Sweep() int { return SweepN(&b.live) }
+
+// Live returns the committed entities, some of which may have been killed
+// since the last Sweep. The slice is valid until the next Commit or Sweep.
+func (b *Buffer[E]) Live() []E { return b.live }
+
+// Len returns the number of committed entities.
+func (b *Buffer[E]) Len() int { return len(b.live) }
@@ -0,0 +1,30 @@
+package ei
+
+import "testing"
+
+func TestBuffer(t *testing.T) {
+	var b Buffer[*payload]
+	b.Spawn(&payload{v: 1})
+	if b.Len() != 0 {
+		t.Fatalf("Len() before Commit = %d, want 0", b.Len())
+	}
+	b.Commit()
+
+	// Each frame, every entity spawns a child and dies.
+	for frame := range 3 {
+		visited := 0
+		for _, e := range b.Live() {
+			visited++
+			b.Spawn(&payload{v: e.v + 1})
+			e.Kill()
+		}
+		if visited != 1 {
+			t.Fatalf("frame %d: visited %d entities, want 1", frame, visited)
+		}
+		b.Sweep()
+		b.Commit()
+		if b.Len() != 1 || b.Live()[0].v != frame+2 {
+			t.Fatalf("frame %d: Live() = %v, want one entity with v = %d", frame, b.Live(), frame+2)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+// Package codec persists the live entities of a collection as JSON. Dead
+// entities are skipped when encoding, and decoded entities are alive.
+package codec
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/eihigh/ei"
+)
+
+// EncodeJSON writes the live elements of xs to w as a JSON array.
+func EncodeJSON[E ei.Interface, S ~[]E](w io.Writer, xs S) error {
+	return json.NewEncoder(w).Encode(alive(xs))
+}
+
+// DecodeJSON reads a JSON array from r and appends its elements to *xs.
+func DecodeJSON[E any, S ~[]E](r io.Reader, xs *S) error {
+	var s S
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return err
+	}
+	*xs = append(*xs, s...)
+	return nil
+}
+
+// EncodeJSONMap writes the live entries of m to w as a JSON object. The key
+// type must be supported by encoding/json.
+func EncodeJSONMap[K comparable, V ei.Interface, M ~map[K]V](w io.Writer, m M) error {
+	return json.NewEncoder(w).Encode(aliveMap(m))
+}
+
+// DecodeJSONMap reads a JSON object from r and adds its entries to *m,
+// allocating the map if it is nil.
+func DecodeJSONMap[K comparable, V any, M ~map[K]V](r io.Reader, m *M) error {
+	return json.NewDecoder(r).Decode(m)
+}
+
+func alive[E ei.Interface, S ~[]E](xs S) S {
+	s := make(S, 0, len(xs))
+	ei.CompactTo(&s, xs)
+	return s
+}
+
+func aliveMap[K comparable, V ei.Interface, M ~map[K]V](m M) M {
+	a, _ := ei.PartitionMap(m)
+	return a
+}
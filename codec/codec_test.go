@@ -0,0 +1,77 @@
+package codec
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/eihigh/ei"
+)
+
+type enemy struct {
+	ei.Entity
+	Name string
+	HP   int
+}
+
+type enemies []*enemy
+
+func TestJSON(t *testing.T) {
+	xs := enemies{{Name: "a", HP: 1}, {Name: "b", HP: 2}, {Name: "c", HP: 3}}
+	xs[1].Kill()
+	var b bytes.Buffer
+	if err := EncodeJSON(&b, xs); err != nil {
+		t.Fatal(err)
+	}
+	got := enemies{{Name: "z"}}
+	if err := DecodeJSON(&b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 || got[1].Name != "a" || got[2].Name != "c" || got[2].HP != 3 {
+		t.Fatalf("decoded %d entities: %+v", len(got), got)
+	}
+	for _, e := range got {
+		if !e.Alive() {
+			t.Errorf("decoded entity %q is dead", e.Name)
+		}
+	}
+}
+
+func TestJSONEmpty(t *testing.T) {
+	var b bytes.Buffer
+	xs := []*enemy{{}}
+	xs[0].Kill()
+	if err := EncodeJSON(&b, xs); err != nil {
+		t.Fatal(err)
+	}
+	if s := strings.TrimSpace(b.String()); s != "[]" {
+		t.Errorf("encoded %s, want []", s)
+	}
+}
+
+func TestJSONMap(t *testing.T) {
+	m := map[string]*enemy{"a": {HP: 1}, "b": {HP: 2}}
+	m["a"].Kill()
+	var b bytes.Buffer
+	if err := EncodeJSONMap(&b, m); err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]*enemy // nil: allocated by DecodeJSONMap
+	if err := DecodeJSONMap(&b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got["b"] == nil || got["b"].HP != 2 {
+		t.Errorf("decoded %v", got)
+	}
+}
+
+func TestJSONMapAdd(t *testing.T) {
+	type byID map[int]*enemy
+	got := byID{1: {Name: "old"}}
+	if err := DecodeJSONMap(strings.NewReader(`{"2":{"Name":"new"}}`), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[1].Name != "old" || got[2].Name != "new" {
+		t.Errorf("decoded %v", got)
+	}
+}
@@ -0,0 +1,87 @@
+package ei
+
+// DeferredEntity is an Entity whose Kill only takes effect on Commit, so
+// that every system sees the same liveness for the rest of the frame.
+type DeferredEntity struct {
+	Entity
+	pending       bool
+	pendingReason any
+}
+
+// Kill marks the entity to be killed on the next Commit. It stays alive
+// until then. Killing a dead entity does nothing.
+func (e *DeferredEntity) Kill() { e.KillWith(nil) }
+
+// TryKill is like Kill, and reports whether the entity was alive and not
+// already marked before.
+func (e *DeferredEntity) TryKill() bool {
+	ok := e.Alive() && !e.pending
+	e.Kill()
+	return ok
+}
+
+// KillWith is like Kill, but the entity is killed with reason as
+// Entity.KillWith does. The last call to Kill or KillWith before Commit decides
+// the reason.
+func (e *DeferredEntity) KillWith(reason any) {
+	if !e.Alive() {
+		return
+	}
+	e.pending = true
+	e.pendingReason = reason
+}
+
+// Reset is like Entity.Reset, and also drops a pending kill.
+func (e *DeferredEntity) Reset() {
+	e.Entity.Reset()
+	e.pending = false
+	e.pendingReason = nil
+}
+
+// Commit applies a pending Kill or KillWith.
+func (e *DeferredEntity) Commit() {
+	if e.pending {
+		reason := e.pendingReason
+		e.pending = false
+		e.pendingReason = nil
+		e.Entity.KillWith(reason)
+	}
+}
+
+// CommitKills calls Commit on every element. Call it right before sweeping.
+func CommitKills[E interface {
+	Interface
+	Commit()
+}, S ~[]E](xs S) {
+	for _, x := range xs {
+		x.Commit()
+	}
+}
+
+// KillQueue collects entities to be killed at a well-defined point, usually
+// the end of the frame, instead of whenever a system decides so.
+type KillQueue[E Interface, S ~[]E] struct {
+	list  *S
+	queue []E
+}
+
+// NewKillQueue returns a queue whose Flush sweeps *list. list may be nil if
+// the caller sweeps by itself, in which case the type arguments must be
+// given:
+//
+//	q := ei.NewKillQueue[*Enemy, []*Enemy](nil)
+func NewKillQueue[E Interface, S ~[]E](list *S) *KillQueue[E, S] {
+	return &KillQueue[E, S]{list: list}
+}
+
+// Enqueue schedules e to be killed on the next Flush.
+func (q *KillQueue[E, S]) Enqueue(e E) { q.queue = append(q.queue, e) }
+
+// Flush kills the queued entities, empties the queue and sweeps the list.
+func (q *KillQueue[E, S]) Flush() {
+	for _, e := range q.queue {
+		e.Kill()
+	}
+	Clear(&q.queue)
+	Sweep(q.list)
+}
@@ -0,0 +1,98 @@
+package ei
+
+import "testing"
+
+func TestDeferredEntity(t *testing.T) {
+	var e DeferredEntity
+	if !e.TryKill() {
+		t.Error("TryKill() = false on a live entity")
+	}
+	if e.TryKill() {
+		t.Error("TryKill() = true on a marked entity")
+	}
+	if !e.Alive() {
+		t.Fatal("entity died before Commit")
+	}
+	e.Commit()
+	if e.Alive() {
+		t.Fatal("entity alive after Commit")
+	}
+}
+
+func TestDeferredEntityKillWith(t *testing.T) {
+	var e DeferredEntity
+	e.KillWith("damage")
+	e.KillWith("timeout")
+	e.Commit()
+	if r := e.Reason(); r != "timeout" {
+		t.Errorf("Reason() = %v, want timeout", r)
+	}
+}
+
+func TestDeferredEntityKillDead(t *testing.T) {
+	var e DeferredEntity
+	e.Kill()
+	e.Commit()
+	e.Kill()
+	e.Revive()
+	e.Commit()
+	if !e.Alive() {
+		t.Error("kill of a dead entity applied after revival")
+	}
+}
+
+func TestDeferredEntityPool(t *testing.T) {
+	pool := Pool[*DeferredEntity]{New: func() *DeferredEntity { return &DeferredEntity{} }}
+	e := pool.Get()
+	xs := []*DeferredEntity{e}
+	e.Kill()
+	CommitKills(xs)
+	e.Kill() // killed again while dead
+	SweepPool(&xs, &pool)
+
+	got := pool.Get()
+	if got != e {
+		t.Fatal("pool did not reuse the entity")
+	}
+	xs = append(xs, got)
+	CommitKills(xs)
+	if !got.Alive() {
+		t.Error("reused entity killed by a stale kill")
+	}
+}
+
+func TestDeferredEntityReset(t *testing.T) {
+	var e DeferredEntity
+	e.Kill()
+	e.Reset()
+	e.Commit()
+	if !e.Alive() {
+		t.Error("Reset did not drop the pending kill")
+	}
+}
+
+func TestKillQueue(t *testing.T) {
+	type entities []*Entity
+	xs := entities{{}, {}, {}}
+	a, b := xs[0], xs[2]
+	q := NewKillQueue(&xs)
+	q.Enqueue(a)
+	q.Enqueue(b)
+	if !a.Alive() || !b.Alive() {
+		t.Fatal("entities killed before Flush")
+	}
+	q.Flush()
+	if len(xs) != 1 || a.Alive() || b.Alive() {
+		t.Errorf("after Flush: len = %d, a alive = %v, b alive = %v", len(xs), a.Alive(), b.Alive())
+	}
+}
+
+func TestKillQueueNil(t *testing.T) {
+	e := &Entity{}
+	q := NewKillQueue[*Entity, []*Entity](nil)
+	q.Enqueue(e)
+	q.Flush()
+	if e.Alive() {
+		t.Error("entity alive after Flush")
+	}
+}
@@ -1,65 +1,1017 @@
 package ei
 
-import "sync/atomic"
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
 
 type Entity struct {
-	dead bool
+	dead   bool
+	frozen bool
+
+	// ext holds the kill reason and the OnKill callbacks. The pointer makes
+	// Entity 16 bytes on 64-bit platforms instead of 2, and the first
+	// KillWith with a reason or OnKill allocates 48 more bytes.
+	ext *entityExt
+}
+
+// entityExt holds the state of Entity and EntityAtomic that most entities
+// never use, so that they stay small.
+type entityExt struct {
+	mu     sync.Mutex // used by EntityAtomic only
+	reason any
+	onKill []func()
+}
+
+func (e *Entity) Kill() { e.TryKill() }
+
+// TryKill kills the entity and reports whether it was alive before.
+func (e *Entity) TryKill() bool { return e.tryKill(nil) }
+
+// KillWith kills the entity and records why, such as damage or a timeout,
+// for Reason to report. It does nothing if the entity is already dead.
+func (e *Entity) KillWith(reason any) { e.tryKill(reason) }
+
+func (e *Entity) tryKill(reason any) bool {
+	if e.dead {
+		return false
+	}
+	e.dead = true
+	if reason != nil && e.ext == nil {
+		e.ext = &entityExt{}
+	}
+	var fs []func()
+	if x := e.ext; x != nil {
+		x.reason = reason
+		fs = x.onKill
+		x.onKill = nil
+	}
+	killCount.Add(1)
+	if OnKill != nil {
+		OnKill(e)
+	}
+	for _, f := range fs {
+		f()
+	}
+	return true
+}
+
+// OnKill registers f to be called once when the entity goes from alive to
+// dead, unlike a Disposer, which runs when it is swept. Callbacks run in
+// registration order. If the entity is already dead, f is never called.
+func (e *Entity) OnKill(f func()) {
+	if e.dead {
+		return
+	}
+	if e.ext == nil {
+		e.ext = &entityExt{}
+	}
+	e.ext.onKill = append(e.ext.onKill, f)
+}
+
+// TryRevive revives the entity and reports whether it was dead before.
+func (e *Entity) TryRevive() bool {
+	if !e.dead {
+		return false
+	}
+	e.dead = false
+	if e.ext != nil {
+		e.ext.reason = nil
+	}
+	spawnCount.Add(1)
+	return true
 }
 
-func (e *Entity) Kill() { e.dead = true }
+// Revive makes a dead entity alive again. If it is revived before the next
+// sweep, it is kept in its slice as if it had never died. If it has already
+// been swept, it is no longer in the slice and must be added again.
+func (e *Entity) Revive() { e.TryRevive() }
 
 func (e *Entity) Alive() bool { return !e.dead }
 
+func (e *Entity) Dead() bool { return e.dead }
+
+// Reason returns the reason given to KillWith, or nil if the entity is
+// alive or was killed without one.
+func (e *Entity) Reason() any {
+	if e.ext == nil {
+		return nil
+	}
+	return e.ext.reason
+}
+
+// Reset returns the entity to its zero state, making it alive again and
+// dropping the callbacks registered by OnKill.
+func (e *Entity) Reset() {
+	e.TryRevive()
+	*e = Entity{}
+}
+
+// Freeze pauses the entity without killing it. A frozen entity survives
+// sweeps but is skipped by SweepEachActive.
+func (e *Entity) Freeze() { e.frozen = true }
+
+func (e *Entity) Unfreeze() { e.frozen = false }
+
+func (e *Entity) Frozen() bool { return e.frozen }
+
+// EntityAtomic is like Entity, but Kill and Alive may be called from
+// multiple goroutines. Only the entity itself is synchronized; slices of
+// entities still need a single sweeper (see SweepConcurrent).
 type EntityAtomic struct {
 	dead atomic.Bool
+
+	// ext is as in Entity. It makes EntityAtomic 16 bytes on 64-bit
+	// platforms instead of 4.
+	ext atomic.Pointer[entityExt]
+}
+
+// extension returns e.ext, allocating it if needed.
+func (e *EntityAtomic) extension() *entityExt {
+	if x := e.ext.Load(); x != nil {
+		return x
+	}
+	e.ext.CompareAndSwap(nil, &entityExt{})
+	return e.ext.Load()
+}
+
+func (e *EntityAtomic) Kill() { e.TryKill() }
+
+// TryKill kills the entity and reports whether this call did so. When
+// several goroutines race to kill the entity, exactly one gets true.
+func (e *EntityAtomic) TryKill() bool { return e.tryKill(nil) }
+
+// KillWith kills the entity and records why, with the same semantics as
+// Entity.KillWith. When several goroutines race, the reason of the one that
+// kills the entity is kept.
+func (e *EntityAtomic) KillWith(reason any) { e.tryKill(reason) }
+
+func (e *EntityAtomic) tryKill(reason any) bool {
+	if !e.dead.CompareAndSwap(false, true) {
+		return false
+	}
+	// OnKill checks dead under x.mu, so a callback registered before the
+	// swap is in x.onKill by the time the lock is taken here.
+	x := e.ext.Load()
+	if reason != nil {
+		x = e.extension()
+	}
+	var fs []func()
+	if x != nil {
+		x.mu.Lock()
+		x.reason = reason
+		fs = x.onKill
+		x.onKill = nil
+		x.mu.Unlock()
+	}
+	killCount.Add(1)
+	if OnKill != nil {
+		OnKill(e)
+	}
+	for _, f := range fs {
+		f()
+	}
+	return true
 }
 
-func (e *EntityAtomic) Kill() { e.dead.Store(true) }
+// OnKill registers f with the same semantics as Entity.OnKill. The callbacks
+// run on the goroutine that kills the entity.
+func (e *EntityAtomic) OnKill(f func()) {
+	if e.dead.Load() {
+		return
+	}
+	x := e.extension()
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if !e.dead.Load() {
+		x.onKill = append(x.onKill, f)
+	}
+}
+
+// TryRevive revives the entity and reports whether this call did so. When
+// several goroutines race to revive the entity, exactly one gets true.
+func (e *EntityAtomic) TryRevive() bool {
+	if !e.dead.CompareAndSwap(true, false) {
+		return false
+	}
+	if x := e.ext.Load(); x != nil {
+		x.mu.Lock()
+		x.reason = nil
+		x.mu.Unlock()
+	}
+	spawnCount.Add(1)
+	return true
+}
+
+// Revive makes a dead entity alive again, with the same semantics as
+// Entity.Revive.
+func (e *EntityAtomic) Revive() { e.TryRevive() }
 
 func (e *EntityAtomic) Alive() bool { return !e.dead.Load() }
 
+func (e *EntityAtomic) Dead() bool { return e.dead.Load() }
+
+// Reason returns the reason given to KillWith, or nil if the entity is
+// alive or was killed without one.
+func (e *EntityAtomic) Reason() any {
+	x := e.ext.Load()
+	if x == nil {
+		return nil
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.reason
+}
+
+// Reset makes the entity alive again and drops the callbacks registered by
+// OnKill.
+func (e *EntityAtomic) Reset() {
+	e.TryRevive()
+	e.ext.Store(nil)
+}
+
 type Interface interface {
 	Kill()
 	Alive() bool
 }
 
+// DeadInterface is implemented by entities that also report Dead, which is
+// always the negation of Alive. Entity and EntityAtomic implement it.
+type DeadInterface interface {
+	Interface
+	Dead() bool
+}
+
+// IsDead reports whether e is dead.
+func IsDead[E Interface](e E) bool { return !e.Alive() }
+
+// Freezable is implemented by entities that can be paused without being
+// killed. Entity implements it.
+type Freezable interface {
+	Freeze()
+	Unfreeze()
+	Frozen() bool
+}
+
+// Resettable is implemented by entities that can be reinitialized for reuse.
+// Types embedding Entity get a Reset that only revives the entity; override
+// it to also clear the payload, calling the embedded Reset.
+type Resettable interface {
+	Reset()
+}
+
+// Reviver is implemented by entities that can be brought back to life.
+// Entity and EntityAtomic implement it.
+type Reviver interface {
+	Revive()
+}
+
+// Disposer is implemented by entities that release resources when they are
+// removed. Functions that remove dead entities and discard them, such as
+// Sweep and SweepMap, call Dispose on each removed entity once per removal.
+// Functions that hand removed entities back to the caller, such as
+// SweepDrain, SweepRecycle and SweepPool, do not.
+type Disposer interface {
+	Dispose()
+}
+
+func dispose(x any) {
+	if d, ok := x.(Disposer); ok {
+		d.Dispose()
+	}
+}
+
+// Recycle prepares a pooled entity for reuse by calling Reset, which clears
+// its payload, and then Revive if e implements Reviver, in case an
+// overriding Reset does not revive it.
+func Recycle[E interface {
+	Interface
+	Resettable
+}](e E) {
+	recycle(e)
+}
+
+// recycle resets e if it implements Resettable, then revives it if it
+// implements Reviver.
+func recycle(e any) {
+	if r, ok := e.(Resettable); ok {
+		r.Reset()
+	}
+	if r, ok := e.(Reviver); ok {
+		r.Revive()
+	}
+}
+
+// Grouped is implemented by entities that belong to a category, such as
+// enemies or bullets, stored in a shared slice.
+type Grouped interface {
+	Group() uint32
+}
+
+// Sweep removes dead elements from the slice in place. The survivors keep
+// their relative order; this is guaranteed and will not change.
+//
+// The slots past the new length are zeroed, so that removed elements can be
+// collected even though they remain in the backing array.
+//
+// A nil xs is treated like a pointer to an empty slice and left alone. The
+// same holds for every function in this package that sweeps through a slice
+// pointer.
 func Sweep[E Interface, S ~[]E](xs *S) {
+	SweepN(xs)
+}
+
+// SweepStable is Sweep under a name that states its order guarantee.
+func SweepStable[E Interface, S ~[]E](xs *S) {
+	Sweep(xs)
+}
+
+// SweepN is like Sweep, but returns the number of removed elements.
+func SweepN[E Interface, S ~[]E](xs *S) int {
+	if xs == nil {
+		return 0
+	}
 	j := 0
 	for _, x := range *xs {
 		if x.Alive() {
 			(*xs)[j] = x
 			j++
+		} else {
+			dispose(x)
 		}
 	}
+	n := len(*xs) - j
+	clear((*xs)[j:])
 	*xs = (*xs)[:j]
+	return n
 }
 
+// SweepUnordered removes dead elements by moving the last element into each
+// vacated slot. It does less copying than Sweep when few elements die, but
+// the survivors do not keep their relative order.
+func SweepUnordered[E Interface, S ~[]E](xs *S) {
+	if xs == nil {
+		return
+	}
+	s := *xs
+	i, n := 0, len(s)
+	for i < n {
+		if s[i].Alive() {
+			i++
+		} else {
+			dispose(s[i])
+			n--
+			s[i] = s[n]
+		}
+	}
+	clear(s[n:])
+	*xs = s[:n]
+}
+
+// SweepEach is like Sweep, but also calls pred for each survivor in order.
+// The index passed to pred is the survivor's position before compaction; use
+// SweepEachNew for the position after compaction.
 func SweepEach[E Interface, S ~[]E](xs *S, pred func(int, E)) {
+	SweepEachN(xs, pred)
+}
+
+// SweepEachN is like SweepEach, but returns the number of removed elements.
+func SweepEachN[E Interface, S ~[]E](xs *S, pred func(int, E)) int {
+	if xs == nil {
+		return 0
+	}
 	j := 0
 	for i, x := range *xs {
 		if x.Alive() {
 			(*xs)[j] = x
 			j++
 			pred(i, x)
+		} else {
+			dispose(x)
+		}
+	}
+	n := len(*xs) - j
+	clear((*xs)[j:])
+	*xs = (*xs)[:j]
+	return n
+}
+
+// SweepPtr is like Sweep for slices of values whose pointers implement
+// Interface, such as []Bullet where Bullet embeds Entity. Liveness is
+// queried through &xs[i].
+func SweepPtr[E any, P interface {
+	*E
+	Interface
+}, S ~[]E](xs *S) {
+	if xs == nil {
+		return
+	}
+	j := 0
+	for i := range *xs {
+		if P(&(*xs)[i]).Alive() {
+			(*xs)[j] = (*xs)[i]
+			j++
+		} else {
+			dispose(P(&(*xs)[i]))
+		}
+	}
+	clear((*xs)[j:])
+	*xs = (*xs)[:j]
+}
+
+// SweepEachPtr is like SweepEach for slices of values whose pointers
+// implement Interface. pred receives a pointer to the survivor at its new
+// position.
+func SweepEachPtr[E any, P interface {
+	*E
+	Interface
+}, S ~[]E](xs *S, pred func(int, P)) {
+	if xs == nil {
+		return
+	}
+	j := 0
+	for i := range *xs {
+		if P(&(*xs)[i]).Alive() {
+			(*xs)[j] = (*xs)[i]
+			pred(i, &(*xs)[j])
+			j++
+		} else {
+			dispose(P(&(*xs)[i]))
+		}
+	}
+	clear((*xs)[j:])
+	*xs = (*xs)[:j]
+}
+
+// SweepNil is like Sweep for slices of pointers, but treats nil elements as
+// dead instead of calling Alive on them, for code that clears entries
+// rather than killing them.
+func SweepNil[E any, P interface {
+	*E
+	Interface
+}, S ~[]P](xs *S) {
+	if xs == nil {
+		return
+	}
+	j := 0
+	for _, x := range *xs {
+		if x != nil && x.Alive() {
+			(*xs)[j] = x
+			j++
+		} else if x != nil {
+			dispose(x)
+		}
+	}
+	clear((*xs)[j:])
+	*xs = (*xs)[:j]
+}
+
+// SweepEachNew is like SweepEach, but passes pred the survivor's position
+// after compaction, which stays valid until the slice is next modified.
+func SweepEachNew[E Interface, S ~[]E](xs *S, pred func(newIndex int, e E)) {
+	if xs == nil {
+		return
+	}
+	j := 0
+	for _, x := range *xs {
+		if x.Alive() {
+			(*xs)[j] = x
+			pred(j, x)
+			j++
+		} else {
+			dispose(x)
+		}
+	}
+	clear((*xs)[j:])
+	*xs = (*xs)[:j]
+}
+
+// SweepEach2 is like SweepEach, but passes pred both the survivor's
+// position before and after compaction.
+func SweepEach2[E Interface, S ~[]E](xs *S, pred func(oldIndex, newIndex int, e E)) {
+	if xs == nil {
+		return
+	}
+	j := 0
+	for i, x := range *xs {
+		if x.Alive() {
+			(*xs)[j] = x
+			pred(i, j, x)
+			j++
+		} else {
+			dispose(x)
+		}
+	}
+	clear((*xs)[j:])
+	*xs = (*xs)[:j]
+}
+
+// SweepChunked is like Sweep, but calls onChunk with the bounds [start, end)
+// of each block of chunk survivors as soon as the block is compacted. The
+// last block may be shorter. If chunk <= 0, the whole result is one block.
+// onChunk is not called for an empty result.
+func SweepChunked[E Interface, S ~[]E](xs *S, chunk int, onChunk func(start, end int)) {
+	if xs == nil {
+		return
+	}
+	start, j := 0, 0
+	for _, x := range *xs {
+		if x.Alive() {
+			(*xs)[j] = x
+			j++
+			if j-start == chunk {
+				onChunk(start, j)
+				start = j
+			}
+		} else {
+			dispose(x)
+		}
+	}
+	clear((*xs)[j:])
+	*xs = (*xs)[:j]
+	if start < j {
+		onChunk(start, j)
+	}
+}
+
+// SweepIndices is like Sweep, but returns the indices of the removed
+// elements in ascending order. The indices refer to positions before
+// compaction, so the same removals can be applied to parallel slices.
+func SweepIndices[E Interface, S ~[]E](xs *S) []int {
+	if xs == nil {
+		return nil
+	}
+	var removed []int
+	j := 0
+	for i, x := range *xs {
+		if x.Alive() {
+			(*xs)[j] = x
+			j++
+		} else {
+			dispose(x)
+			removed = append(removed, i)
+		}
+	}
+	clear((*xs)[j:])
+	*xs = (*xs)[:j]
+	return removed
+}
+
+// SweepMoved is like Sweep, but calls onMove for each survivor that is moved
+// to a lower index during compaction. Survivors that stay in place are not
+// reported.
+func SweepMoved[E Interface, S ~[]E](xs *S, onMove func(oldIndex, newIndex int, e E)) {
+	if xs == nil {
+		return
+	}
+	j := 0
+	for i, x := range *xs {
+		if x.Alive() {
+			if i != j {
+				(*xs)[j] = x
+				onMove(i, j, x)
+			}
+			j++
+		} else {
+			dispose(x)
+		}
+	}
+	clear((*xs)[j:])
+	*xs = (*xs)[:j]
+}
+
+// SweepRemap is like Sweep, but fills a mapping from each old index to the
+// new index of the element, or -1 if it was removed, so that structures
+// holding indices can be patched. It reuses the capacity of remap and
+// returns the mapping.
+func SweepRemap[E Interface, S ~[]E](xs *S, remap []int) []int {
+	if xs == nil {
+		return remap[:0]
+	}
+	remap = slices.Grow(remap[:0], len(*xs))[:len(*xs)]
+	j := 0
+	for i, x := range *xs {
+		if x.Alive() {
+			(*xs)[j] = x
+			remap[i] = j
+			j++
+		} else {
+			dispose(x)
+			remap[i] = -1
+		}
+	}
+	clear((*xs)[j:])
+	*xs = (*xs)[:j]
+	return remap
+}
+
+// SweepZip removes dead elements from xs and the elements at the same
+// indices from ys, keeping both aligned and in order. A nil pointer counts
+// as an empty slice. It panics if the slices have different lengths.
+func SweepZip[E Interface, S ~[]E, T any, U ~[]T](xs *S, ys *U) {
+	n, m := 0, 0
+	if xs != nil {
+		n = len(*xs)
+	}
+	if ys != nil {
+		m = len(*ys)
+	}
+	if n != m {
+		panic("ei: SweepZip: length mismatch")
+	}
+	if n == 0 {
+		return
+	}
+	j := 0
+	for i, x := range *xs {
+		if x.Alive() {
+			(*xs)[j] = x
+			(*ys)[j] = (*ys)[i]
+			j++
+		} else {
+			dispose(x)
 		}
 	}
+	clear((*xs)[j:])
 	*xs = (*xs)[:j]
+	clear((*ys)[j:])
+	*ys = (*ys)[:j]
+}
+
+// SweepSwap removes dead elements like Sweep, calling swap(i, j) for every
+// pair of elements it exchanges, so that external data can be kept aligned
+// with the same closure as a sort.Interface's Swap.
+//
+// Unlike Sweep, which assigns survivors over dead elements, SweepSwap swaps
+// each survivor with the first dead element before it, so the dead elements
+// end up past the new length in unspecified order. Applying the same
+// swaps to a parallel slice and truncating it to the new length removes the
+// same elements.
+func SweepSwap[E Interface, S ~[]E](xs *S, swap func(i, j int)) {
+	if xs == nil {
+		return
+	}
+	s := *xs
+	j := 0
+	for i, x := range s {
+		if x.Alive() {
+			if i != j {
+				s[i], s[j] = s[j], s[i]
+				swap(i, j)
+			}
+			j++
+		} else {
+			dispose(x)
+		}
+	}
+	clear(s[j:])
+	*xs = s[:j]
+}
+
+// MarkDead records the liveness of each element into a mask, reusing the
+// capacity of out, and returns the mask. Despite the name, mask[i] is true
+// if xs[i] is alive, so the mask can be passed to CompactMasked as keep.
+func MarkDead[E Interface, S ~[]E](xs S, out []bool) []bool {
+	out = slices.Grow(out[:0], len(xs))[:len(xs)]
+	for i, x := range xs {
+		out[i] = x.Alive()
+	}
+	return out
+}
+
+// CompactMasked removes the elements whose keep entry is false, keeping the
+// rest in order. Together with MarkDead it splits Sweep into two phases, and
+// the same mask can compact parallel slices. Removed elements are disposed
+// as by Sweep. It panics if keep and the slice have different lengths.
+func CompactMasked[E any, S ~[]E](xs *S, keep []bool) {
+	if xs == nil {
+		return
+	}
+	if len(keep) != len(*xs) {
+		panic("ei: CompactMasked: mask length mismatch")
+	}
+	j := 0
+	for i, x := range *xs {
+		if keep[i] {
+			(*xs)[j] = x
+			j++
+		} else {
+			dispose(x)
+		}
+	}
+	clear((*xs)[j:])
+	*xs = (*xs)[:j]
+}
+
+// SweepEachErr is like SweepEach, but stops calling pred as soon as it
+// returns a non-nil error. The remaining elements are still compacted without
+// calling pred, so the slice is fully swept when the error is returned.
+func SweepEachErr[E Interface, S ~[]E](xs *S, pred func(int, E) error) error {
+	if xs == nil {
+		return nil
+	}
+	var err error
+	j := 0
+	for i, x := range *xs {
+		if x.Alive() {
+			(*xs)[j] = x
+			j++
+			if err == nil {
+				err = pred(i, x)
+			}
+		} else {
+			dispose(x)
+		}
+	}
+	clear((*xs)[j:])
+	*xs = (*xs)[:j]
+	return err
+}
+
+// SweepEachReverse removes dead elements like Sweep, then calls pred for the
+// survivors from last to first. The index passed to pred is the position
+// after compaction, and the survivors keep their relative order.
+func SweepEachReverse[E Interface, S ~[]E](xs *S, pred func(int, E)) {
+	if xs == nil {
+		return
+	}
+	Sweep(xs)
+	for i := len(*xs) - 1; i >= 0; i-- {
+		pred(i, (*xs)[i])
+	}
+}
+
+// SweepSorted removes dead elements like Sweep, then stably sorts the
+// survivors by less. It returns the number of survivors.
+func SweepSorted[E Interface, S ~[]E](xs *S, less func(a, b E) bool) int {
+	if xs == nil {
+		return 0
+	}
+	Sweep(xs)
+	s := *xs
+	sort.SliceStable(s, func(i, j int) bool { return less(s[i], s[j]) })
+	return len(s)
+}
+
+// SweepEachActive is like SweepEach, but does not call pred for frozen
+// survivors. Frozen entities are kept; only dead ones are removed.
+func SweepEachActive[E interface {
+	Interface
+	Freezable
+}, S ~[]E](xs *S, pred func(int, E)) {
+	SweepEach(xs, func(i int, x E) {
+		if !x.Frozen() {
+			pred(i, x)
+		}
+	})
+}
+
+// SweepEachGroup is like SweepEach, but calls pred only for live elements
+// of the given group. Dead elements of any group are still removed.
+func SweepEachGroup[E interface {
+	Interface
+	Grouped
+}, S ~[]E](xs *S, group uint32, pred func(int, E)) {
+	SweepEach(xs, func(i int, x E) {
+		if x.Group() == group {
+			pred(i, x)
+		}
+	})
 }
 
 func SweepMap[K comparable, V Interface, M ~map[K]V](m M) {
+	SweepMapN(m)
+}
+
+// SweepMapN is like SweepMap, but returns the number of deleted entries.
+func SweepMapN[K comparable, V Interface, M ~map[K]V](m M) int {
+	n := 0
 	for k, v := range m {
 		if !v.Alive() {
 			delete(m, k)
+			dispose(v)
+			n++
 		}
 	}
+	return n
 }
 
+// SweepSet deletes the dead elements of a set such as map[*Enemy]struct{}
+// and returns the number deleted.
+func SweepSet[E interface {
+	Interface
+	comparable
+}, S ~map[E]struct{}](s S) int {
+	n := 0
+	for e := range s {
+		if !e.Alive() {
+			delete(s, e)
+			dispose(e)
+			n++
+		}
+	}
+	return n
+}
+
+// SweepEachMap is like SweepMap, but also calls pred for each surviving
+// entry. The keys are snapshotted before iterating, so pred may add entries,
+// which are not visited in this pass, or delete entries, which are skipped.
+// The snapshot costs one allocation per call.
 func SweepEachMap[K comparable, V Interface, M ~map[K]V](m M, pred func(K, V)) {
-	for k, v := range m {
+	SweepEachMapN(m, pred)
+}
+
+// SweepEachMapN is like SweepEachMap, but returns the number of deleted
+// entries.
+func SweepEachMapN[K comparable, V Interface, M ~map[K]V](m M, pred func(K, V)) int {
+	n := 0
+	for _, k := range slices.Collect(maps.Keys(m)) {
+		v, ok := m[k]
+		if !ok {
+			continue // deleted by pred
+		}
+		if !v.Alive() {
+			delete(m, k)
+			dispose(v)
+			n++
+		} else {
+			pred(k, v)
+		}
+	}
+	return n
+}
+
+// ShrinkFactor is the ratio of capacity to length above which SweepShrink
+// reallocates the slice.
+const ShrinkFactor = 4
+
+// SweepShrink is like Sweep, but if the surviving elements occupy less than
+// 1/ShrinkFactor of the capacity, it moves them into a right-sized slice so
+// that the old backing array can be collected.
+func SweepShrink[E Interface, S ~[]E](xs *S) {
+	SweepShrinkFrac(xs, 1.0/ShrinkFactor)
+}
+
+// SweepShrinkFrac is like SweepShrink, but reallocates when the surviving
+// elements occupy less than frac of the capacity. A frac of 0 never
+// reallocates, and a frac of 1 reallocates whenever there is spare capacity.
+func SweepShrinkFrac[E Interface, S ~[]E](xs *S, frac float64) {
+	if xs == nil {
+		return
+	}
+	Sweep(xs)
+	if float64(len(*xs)) < frac*float64(cap(*xs)) {
+		s := make(S, len(*xs))
+		copy(s, *xs)
+		*xs = s
+	}
+}
+
+// SweepInto sets *dst to the live elements of src, reusing the capacity of
+// *dst. Unlike Sweep, src is left untouched, which allows ping-ponging
+// between two preallocated slices across frames:
+//
+//	ei.SweepInto(&back, front)
+//	front, back = back, front
+//
+// dst and src must not share a backing array. It panics if dst is nil.
+func SweepInto[E Interface, S ~[]E](dst *S, src S) {
+	if dst == nil {
+		panic("ei: SweepInto: nil dst")
+	}
+	Clear(dst)
+	for _, x := range src {
+		if x.Alive() {
+			*dst = append(*dst, x)
+		}
+	}
+}
+
+// SweepDrain is like Sweep, but appends the removed elements to *dst in
+// their original order instead of discarding them, so that teardown logic
+// can run after the compaction.
+func SweepDrain[E Interface, S ~[]E](xs *S, dst *S) {
+	if xs == nil {
+		return
+	}
+	j := 0
+	for _, x := range *xs {
+		if x.Alive() {
+			(*xs)[j] = x
+			j++
+		} else {
+			*dst = append(*dst, x)
+		}
+	}
+	clear((*xs)[j:])
+	*xs = (*xs)[:j]
+}
+
+// SweepRecycle is like Sweep, but appends the removed elements to *dead for
+// reuse. Each removed element is prepared as by Recycle before it is
+// appended, so *dead holds entities that are ready to be spawned again.
+func SweepRecycle[E interface {
+	Interface
+	Resettable
+}, S ~[]E](live *S, dead *S) {
+	if live == nil {
+		return
+	}
+	j := 0
+	for _, x := range *live {
+		if x.Alive() {
+			(*live)[j] = x
+			j++
+		} else {
+			recycle(x)
+			*dead = append(*dead, x)
+		}
+	}
+	clear((*live)[j:])
+	*live = (*live)[:j]
+}
+
+// CompactTo appends the live elements of src to *dst and returns how many
+// were appended. Unlike SweepInto, *dst is not cleared first, so survivors
+// of several slices can be accumulated into one. src is left untouched.
+func CompactTo[E Interface, S ~[]E](dst *S, src S) int {
+	n := len(*dst)
+	for _, x := range src {
+		if x.Alive() {
+			*dst = append(*dst, x)
+		}
+	}
+	return len(*dst) - n
+}
+
+// SweepConcurrent is like Sweep, but may run while other goroutines call Kill
+// on the elements, as long as their liveness is synchronized as with
+// EntityAtomic. Each element's Alive is evaluated exactly once, so an element
+// killed during the sweep is either removed now or by the next sweep.
+//
+// The slice itself is not synchronized. If other goroutines read or append to
+// it, they must hold mu, which SweepConcurrent holds while compacting. mu may
+// be nil if the sweeper is the only goroutine touching the slice.
+func SweepConcurrent[E Interface, S ~[]E](mu sync.Locker, xs *S) {
+	if mu != nil {
+		mu.Lock()
+		defer mu.Unlock()
+	}
+	Sweep(xs)
+}
+
+// SweepEachMapErr is like SweepEachMap, but stops calling pred as soon as
+// it returns a non-nil error. The remaining dead entries are still deleted,
+// so the map is fully swept when the error is returned.
+func SweepEachMapErr[K comparable, V Interface, M ~map[K]V](m M, pred func(K, V) error) error {
+	var err error
+	for _, k := range slices.Collect(maps.Keys(m)) {
+		v, ok := m[k]
+		if !ok {
+			continue // deleted by pred
+		}
 		if !v.Alive() {
 			delete(m, k)
+			dispose(v)
+		} else if err == nil {
+			err = pred(k, v)
+		}
+	}
+	return err
+}
+
+// SweepEachMapSorted is like SweepEachMap, but calls pred in ascending key
+// order, which makes the iteration deterministic. It allocates and sorts a
+// slice of all keys on every call.
+func SweepEachMapSorted[K cmp.Ordered, V Interface, M ~map[K]V](m M, pred func(K, V)) {
+	for _, k := range slices.Sorted(maps.Keys(m)) {
+		v, ok := m[k]
+		if !ok {
+			continue // deleted by pred
+		}
+		if !v.Alive() {
+			delete(m, k)
+			dispose(v)
 		} else {
 			pred(k, v)
 		}
 	}
 }
+
+// SweepRemovedMap is like SweepMap, but returns the keys of the deleted
+// entries. The order of the keys is unspecified.
+func SweepRemovedMap[K comparable, V Interface, M ~map[K]V](m M) []K {
+	var removed []K
+	for k, v := range m {
+		if !v.Alive() {
+			delete(m, k)
+			dispose(v)
+			removed = append(removed, k)
+		}
+	}
+	return removed
+}
@@ -0,0 +1,1031 @@
+package ei
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"unsafe"
+)
+
+func TestEntitySize(t *testing.T) {
+	// Entity is embedded in every bullet and particle, so keep the rarely
+	// used state out of line.
+	if n := unsafe.Sizeof(Entity{}); n > 16 {
+		t.Errorf("unsafe.Sizeof(Entity{}) = %d, want <= 16", n)
+	}
+	if n := unsafe.Sizeof(EntityAtomic{}); n > 16 {
+		t.Errorf("unsafe.Sizeof(EntityAtomic{}) = %d, want <= 16", n)
+	}
+}
+
+func TestEntityKillWith(t *testing.T) {
+	var e Entity
+	if e.Reason() != nil {
+		t.Errorf("Reason() of a live entity = %v", e.Reason())
+	}
+	e.KillWith("damage")
+	e.KillWith("timeout")
+	if r := e.Reason(); r != "damage" {
+		t.Errorf("Reason() = %v, want damage", r)
+	}
+	e.Revive()
+	if r := e.Reason(); r != nil {
+		t.Errorf("Reason() after Revive = %v, want nil", r)
+	}
+	e.Kill()
+	if r := e.Reason(); r != nil {
+		t.Errorf("Reason() after Kill = %v, want nil", r)
+	}
+}
+
+func TestEntityOnKill(t *testing.T) {
+	var e Entity
+	var got []int
+	e.OnKill(func() { got = append(got, 1) })
+	e.OnKill(func() { got = append(got, 2) })
+	e.Kill()
+	e.Kill()
+	e.OnKill(func() { got = append(got, 3) }) // dead: never called
+	e.Revive()
+	e.Kill()
+	if len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("callbacks ran as %v, want [1 2]", got)
+	}
+
+	var r Entity
+	r.OnKill(func() { t.Error("callback ran after Reset") })
+	r.Reset()
+	r.Kill()
+}
+
+func TestEntityAtomicKillWith(t *testing.T) {
+	var e EntityAtomic
+	var wg sync.WaitGroup
+	var wins atomic.Int32
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			e.KillWith(i)
+			if e.Reason() == nil {
+				t.Error("Reason() = nil after KillWith")
+			}
+		}()
+	}
+	e.OnKill(func() { wins.Add(1) })
+	wg.Wait()
+	if e.Alive() {
+		t.Fatal("entity alive after KillWith")
+	}
+	if n := wins.Load(); n > 1 {
+		t.Errorf("OnKill callback ran %d times", n)
+	}
+	e.Reset()
+	if e.Dead() || e.Reason() != nil {
+		t.Errorf("after Reset: dead = %v, reason = %v", e.Dead(), e.Reason())
+	}
+}
+
+func TestEntityAtomicOnKill(t *testing.T) {
+	for range 100 {
+		var e EntityAtomic
+		var n atomic.Int32
+		for range 4 {
+			e.OnKill(func() { n.Add(1) })
+		}
+		var wg sync.WaitGroup
+		for range 4 {
+			wg.Add(2)
+			go func() { defer wg.Done(); e.OnKill(func() { n.Add(1) }) }()
+			go func() { defer wg.Done(); e.Kill() }()
+		}
+		wg.Wait()
+		// The first four callbacks run once each. The racing ones either
+		// run once or are registered too late.
+		if got := n.Load(); got < 4 || got > 8 {
+			t.Fatalf("callbacks ran %d times, want 4 to 8", got)
+		}
+	}
+}
+
+func TestSweepZip(t *testing.T) {
+	xs := []*Entity{{}, {}, {}, {}}
+	ys := []string{"a", "b", "c", "d"}
+	xs[1].Kill()
+	xs[2].Kill()
+	a, d := xs[0], xs[3]
+	SweepZip(&xs, &ys)
+	if len(xs) != 2 || xs[0] != a || xs[1] != d {
+		t.Errorf("xs = %v, want [a d]", xs)
+	}
+	if len(ys) != 2 || ys[0] != "a" || ys[1] != "d" {
+		t.Errorf("ys = %v, want [a d]", ys)
+	}
+	if full := ys[:4]; full[2] != "" || full[3] != "" {
+		t.Errorf("tail of ys not zeroed: %q", full)
+	}
+}
+
+func TestSweepZipNil(t *testing.T) {
+	var xs []*Entity
+	SweepZip[*Entity, []*Entity, int, []int](&xs, nil)
+	SweepZip[*Entity, []*Entity, int, []int](nil, nil)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("no panic on a nil ys with a non-empty xs")
+		}
+	}()
+	xs = []*Entity{{dead: true}}
+	SweepZip[*Entity, []*Entity, int, []int](&xs, nil)
+}
+
+func TestSweepZipMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("no panic on a length mismatch")
+		}
+	}()
+	xs := []*Entity{{}}
+	ys := []int{1, 2}
+	SweepZip(&xs, &ys)
+}
+
+func TestSweepInto(t *testing.T) {
+	front := []*Entity{{}, {}, {}}
+	front[1].Kill()
+	back := make([]*Entity, 0, 8)
+	back = append(back, &Entity{}) // stale contents are dropped
+	p := &back[0]
+
+	SweepInto(&back, front)
+	if len(back) != 2 || back[0] != front[0] || back[1] != front[2] {
+		t.Errorf("back = %v, want the live elements of front", back)
+	}
+	if &back[0] != p {
+		t.Error("SweepInto reallocated a large enough dst")
+	}
+	if len(front) != 3 {
+		t.Errorf("src modified: len = %d", len(front))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("no panic on a nil dst")
+		}
+	}()
+	SweepInto(nil, front)
+}
+
+// churn kills every other element of xs, shifted by frame, to emulate a
+// frame of a high-churn workload.
+func churn(xs []*Entity, frame int) {
+	for i, x := range xs {
+		if (i+frame)%2 == 0 {
+			x.dead = true
+		}
+	}
+}
+
+func newEntities(n int) []*Entity {
+	xs := make([]*Entity, n)
+	for i := range xs {
+		xs[i] = &Entity{}
+	}
+	return xs
+}
+
+func BenchmarkSweep(b *testing.B) {
+	all := newEntities(1 << 12)
+	xs := make([]*Entity, 0, len(all))
+	for i := range b.N {
+		xs = append(xs[:0], all...)
+		churn(xs, i)
+		Sweep(&xs)
+		for _, x := range all {
+			x.dead = false
+		}
+	}
+}
+
+func BenchmarkSweepInto(b *testing.B) {
+	all := newEntities(1 << 12)
+	front := make([]*Entity, 0, len(all))
+	back := make([]*Entity, 0, len(all))
+	for i := range b.N {
+		front = append(front[:0], all...)
+		churn(front, i)
+		SweepInto(&back, front)
+		front, back = back, front
+		for _, x := range all {
+			x.dead = false
+		}
+	}
+}
+
+// payload is an entity whose Reset clears its payload without reviving it.
+type payload struct {
+	Entity
+	v int
+}
+
+func (p *payload) Reset() { p.v = 0 }
+
+func TestSweepRecycle(t *testing.T) {
+	live := []*payload{{v: 1}, {v: 2}, {v: 3}}
+	live[1].Kill()
+	var dead []*payload
+	SweepRecycle(&live, &dead)
+	if len(live) != 2 || live[0].v != 1 || live[1].v != 3 {
+		t.Errorf("live = %v", live)
+	}
+	if len(dead) != 1 || dead[0].v != 0 || !dead[0].Alive() {
+		t.Errorf("dead[0] = %+v, want a reset, live entity", dead[0])
+	}
+}
+
+func TestSweepShrink(t *testing.T) {
+	xs := newEntities(100)
+	for _, x := range xs[10:] {
+		x.Kill()
+	}
+	SweepShrink(&xs)
+	if len(xs) != 10 || cap(xs) != 10 {
+		t.Errorf("len, cap = %d, %d, want 10, 10", len(xs), cap(xs))
+	}
+
+	// Few removals keep the backing array.
+	xs = newEntities(8)
+	xs[0].Kill()
+	p := &xs[0]
+	SweepShrink(&xs)
+	if len(xs) != 7 || &xs[0] != p {
+		t.Errorf("len = %d, reallocated = %v, want 7, false", len(xs), &xs[0] != p)
+	}
+}
+
+func TestSweepConcurrent(t *testing.T) {
+	var mu sync.Mutex
+	var xs []*atomicEntity
+	all := make([]*atomicEntity, 1000)
+	for i := range all {
+		all[i] = &atomicEntity{n: i}
+	}
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for _, x := range all {
+			mu.Lock()
+			xs = append(xs, x)
+			mu.Unlock()
+			if x.n%2 == 0 {
+				x.Kill()
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			SweepConcurrent(&mu, &xs)
+		}
+	}()
+	wg.Wait()
+	SweepConcurrent(nil, &xs)
+	if len(xs) != 500 {
+		t.Fatalf("len(xs) = %d, want 500", len(xs))
+	}
+	for i, x := range xs {
+		if x.n != 2*i+1 {
+			t.Fatalf("xs[%d].n = %d, want %d", i, x.n, 2*i+1)
+		}
+	}
+}
+
+// member is an entity belonging to a group.
+type member struct {
+	Entity
+	group uint32
+}
+
+func (m *member) Group() uint32 { return m.group }
+
+func TestSweepEachGroup(t *testing.T) {
+	xs := []*member{{group: 1}, {group: 2}, {group: 1}, {group: 2}}
+	xs[2].Kill()
+	xs[3].Kill()
+	var got []int
+	SweepEachGroup(&xs, 1, func(i int, m *member) { got = append(got, i) })
+	if len(got) != 1 || got[0] != 0 {
+		t.Errorf("pred called with %v, want [0]", got)
+	}
+	if len(xs) != 2 {
+		t.Errorf("len(xs) = %d, want 2: dead elements of other groups must be removed", len(xs))
+	}
+}
+
+func TestSweepEachReverse(t *testing.T) {
+	xs := newEntities(4)
+	xs[1].Kill()
+	want := []*Entity{xs[0], xs[2], xs[3]}
+	var got []int
+	SweepEachReverse(&xs, func(i int, x *Entity) {
+		if x != want[i] {
+			t.Errorf("pred(%d, %p), want %p", i, x, want[i])
+		}
+		got = append(got, i)
+	})
+	if len(got) != 3 || got[0] != 2 || got[1] != 1 || got[2] != 0 {
+		t.Errorf("pred called with %v, want [2 1 0]", got)
+	}
+	if len(xs) != 3 || xs[0] != want[0] || xs[1] != want[1] || xs[2] != want[2] {
+		t.Errorf("xs = %v, want %v", xs, want)
+	}
+}
+
+func TestSweepEachErr(t *testing.T) {
+	xs := newEntities(5)
+	xs[3].Kill()
+	errStop := errors.New("stop")
+	var calls []int
+	err := SweepEachErr(&xs, func(i int, x *Entity) error {
+		calls = append(calls, i)
+		if i == 1 {
+			return errStop
+		}
+		return nil
+	})
+	if err != errStop {
+		t.Errorf("err = %v, want %v", err, errStop)
+	}
+	if len(calls) != 2 {
+		t.Errorf("pred called with %v, want [0 1]", calls)
+	}
+	if len(xs) != 4 {
+		t.Errorf("len(xs) = %d, want 4: the sweep must finish after an error", len(xs))
+	}
+}
+
+func TestSweepIndices(t *testing.T) {
+	xs := newEntities(6)
+	for _, i := range []int{0, 2, 3, 5} {
+		xs[i].Kill()
+	}
+	b, e := xs[1], xs[4]
+	got := SweepIndices(&xs)
+	if len(got) != 4 || got[0] != 0 || got[1] != 2 || got[2] != 3 || got[3] != 5 {
+		t.Errorf("SweepIndices() = %v, want [0 2 3 5]", got)
+	}
+	if len(xs) != 2 || xs[0] != b || xs[1] != e {
+		t.Errorf("xs = %v, want [b e]", xs)
+	}
+	if got := SweepIndices(&xs); got != nil {
+		t.Errorf("SweepIndices() without dead elements = %v, want nil", got)
+	}
+}
+
+func TestSweepMoved(t *testing.T) {
+	xs := newEntities(5)
+	xs[2].Kill()
+	d, e := xs[3], xs[4]
+	type move struct {
+		old, new int
+		e        *Entity
+	}
+	var got []move
+	SweepMoved(&xs, func(o, n int, x *Entity) { got = append(got, move{o, n, x}) })
+	want := []move{{3, 2, d}, {4, 3, e}}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("moves = %v, want %v", got, want)
+	}
+	if len(xs) != 4 {
+		t.Errorf("len(xs) = %d, want 4", len(xs))
+	}
+}
+
+func TestSweepRemovedMap(t *testing.T) {
+	m := map[int]*Entity{1: {}, 2: {}, 3: {}, 4: {}}
+	m[2].Kill()
+	m[4].Kill()
+	got := SweepRemovedMap(m)
+	slices.Sort(got)
+	if !slices.Equal(got, []int{2, 4}) {
+		t.Errorf("SweepRemovedMap() = %v, want [2 4]", got)
+	}
+	if len(m) != 2 || m[1] == nil || m[3] == nil {
+		t.Errorf("m = %v, want keys 1 and 3", m)
+	}
+}
+
+func TestSweepSorted(t *testing.T) {
+	xs := []*member{{group: 2}, {group: 1}, {group: 3}, {group: 1}, {group: 2}}
+	xs[2].Kill()
+	want := []*member{xs[1], xs[3], xs[0], xs[4]} // stable among equal groups
+	n := SweepSorted(&xs, func(a, b *member) bool { return a.group < b.group })
+	if n != 4 || !slices.Equal(xs, want) {
+		t.Errorf("SweepSorted() = %d, %v, want 4, %v", n, xs, want)
+	}
+}
+
+func TestSweepMapN(t *testing.T) {
+	m := map[string]*disposed{"a": {}, "b": {}, "c": {}}
+	m["a"].Kill()
+	m["c"].Kill()
+	a := m["a"]
+	if n := SweepMapN(m); n != 2 {
+		t.Errorf("SweepMapN() = %d, want 2", n)
+	}
+	if len(m) != 1 || m["b"] == nil {
+		t.Errorf("m = %v, want only b", m)
+	}
+	if a.n != 1 {
+		t.Errorf("removed entry disposed %d times, want 1", a.n)
+	}
+	if n := SweepMapN(m); n != 0 {
+		t.Errorf("SweepMapN() on a live map = %d, want 0", n)
+	}
+}
+
+func TestSweepN(t *testing.T) {
+	all := newEntities(3)
+	KillAll(all)
+	for _, tt := range []struct {
+		name string
+		xs   []*Entity
+		n    int
+	}{
+		{"empty", nil, 0},
+		{"all dead", all, 3},
+		{"mixed", []*Entity{{}, {dead: true}, {}}, 1},
+	} {
+		l := len(tt.xs)
+		if n := SweepN(&tt.xs); n != tt.n || len(tt.xs) != l-tt.n {
+			t.Errorf("%s: SweepN() = %d, len = %d, want %d, %d", tt.name, n, len(tt.xs), tt.n, l-tt.n)
+		}
+	}
+}
+
+func TestSweepEachMapSorted(t *testing.T) {
+	m := map[int]*Entity{}
+	for k := range 20 {
+		m[k] = &Entity{dead: k%3 == 0}
+	}
+	var got []int
+	SweepEachMapSorted(m, func(k int, _ *Entity) { got = append(got, k) })
+	if !slices.IsSorted(got) || len(got) != 13 {
+		t.Errorf("pred called with keys %v, want the 13 live keys in order", got)
+	}
+	if len(m) != 13 {
+		t.Errorf("len(m) = %d, want 13", len(m))
+	}
+}
+
+func TestSweepStable(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 100 {
+		xs := make([]*payload, r.IntN(50))
+		var want []*payload
+		for i := range xs {
+			xs[i] = &payload{v: i}
+			if r.IntN(3) == 0 {
+				xs[i].Kill()
+			} else {
+				want = append(want, xs[i])
+			}
+		}
+		ys := slices.Clone(xs)
+		Sweep(&xs)
+		SweepStable(&ys)
+		if !slices.Equal(xs, want) || !slices.Equal(ys, want) {
+			t.Fatalf("order not kept:\nSweep       %v\nSweepStable %v\nwant        %v", xs, ys, want)
+		}
+	}
+}
+
+func TestSweepPtr(t *testing.T) {
+	xs := []payload{{v: 1}, {v: 2}, {v: 3}}
+	xs[0].Kill()
+	SweepPtr(&xs)
+	if len(xs) != 2 || xs[0].v != 2 || xs[1].v != 3 {
+		t.Errorf("xs = %+v, want [2 3]", xs)
+	}
+
+	xs[0].Kill()
+	SweepEachPtr(&xs, func(i int, p *payload) {
+		if i != 1 || p != &xs[0] {
+			t.Errorf("pred(%d, %p), want 1, &xs[0]", i, p)
+		}
+		p.v = 4
+	})
+	if len(xs) != 1 || xs[0].v != 4 {
+		t.Errorf("xs = %+v, want [4]", xs)
+	}
+}
+
+func TestDead(t *testing.T) {
+	var e Entity
+	var a EntityAtomic
+	for range 2 {
+		if e.Dead() == e.Alive() || a.Dead() == a.Alive() {
+			t.Errorf("Dead() is not the negation of Alive()")
+		}
+		if IsDead(&e) != e.Dead() {
+			t.Errorf("IsDead() = %v, want %v", IsDead(&e), e.Dead())
+		}
+		e.Kill()
+		a.Kill()
+	}
+}
+
+func TestRecycle(t *testing.T) {
+	p := &payload{v: 1}
+	for range 3 {
+		p.Kill()
+		Recycle(p)
+		if !p.Alive() || p.v != 0 {
+			t.Fatalf("after Recycle: alive = %v, v = %d", p.Alive(), p.v)
+		}
+		p.v = 1
+	}
+
+	var e Entity
+	e.Kill()
+	e.Reset()
+	if !e.Alive() {
+		t.Error("Entity.Reset did not revive")
+	}
+}
+
+func TestSweepEachNew(t *testing.T) {
+	xs := newEntities(4)
+	xs[1].Kill()
+	var before, after []int
+	SweepEach(&xs, func(i int, _ *Entity) { before = append(before, i) })
+	xs = append(xs[:1], append([]*Entity{{dead: true}}, xs[1:]...)...)
+	SweepEachNew(&xs, func(i int, x *Entity) {
+		if xs[i] != x {
+			t.Errorf("xs[%d] != x", i)
+		}
+		after = append(after, i)
+	})
+	if !slices.Equal(before, []int{0, 2, 3}) || !slices.Equal(after, []int{0, 1, 2}) {
+		t.Errorf("SweepEach indices = %v, SweepEachNew indices = %v, want [0 2 3], [0 1 2]", before, after)
+	}
+}
+
+func TestSweepChunked(t *testing.T) {
+	xs := newEntities(8)
+	xs[2].Kill()
+	type chunk struct{ start, end int }
+	var got []chunk
+	SweepChunked(&xs, 3, func(start, end int) { got = append(got, chunk{start, end}) })
+	if !slices.Equal(got, []chunk{{0, 3}, {3, 6}, {6, 7}}) {
+		t.Errorf("chunks = %v, want [{0 3} {3 6} {6 7}]", got)
+	}
+
+	got = got[:0]
+	SweepChunked(&xs, 0, func(start, end int) { got = append(got, chunk{start, end}) })
+	if !slices.Equal(got, []chunk{{0, 7}}) {
+		t.Errorf("chunks with chunk = 0: %v, want [{0 7}]", got)
+	}
+
+	KillAll(xs)
+	SweepChunked(&xs, 3, func(start, end int) { t.Errorf("onChunk(%d, %d) for an empty result", start, end) })
+}
+
+func BenchmarkSweepChunked(b *testing.B) {
+	all := newEntities(1 << 12)
+	xs := make([]*Entity, 0, len(all))
+	for i := range b.N {
+		xs = append(xs[:0], all...)
+		churn(xs, i)
+		SweepChunked(&xs, 256, func(start, end int) {})
+		for _, x := range all {
+			x.dead = false
+		}
+	}
+}
+
+func TestSweepEachActive(t *testing.T) {
+	xs := newEntities(3)
+	xs[0].Freeze()
+	xs[1].Kill()
+	var got []int
+	SweepEachActive(&xs, func(i int, _ *Entity) { got = append(got, i) })
+	if !slices.Equal(got, []int{2}) || len(xs) != 2 {
+		t.Errorf("pred called with %v, len = %d, want [2], 2", got, len(xs))
+	}
+	xs[0].Unfreeze()
+	got = got[:0]
+	SweepEachActive(&xs, func(i int, _ *Entity) { got = append(got, i) })
+	if !slices.Equal(got, []int{0, 1}) {
+		t.Errorf("pred called with %v after Unfreeze, want [0 1]", got)
+	}
+}
+
+func TestCompactTo(t *testing.T) {
+	a, b := mixed(), mixed()
+	var dst []*Entity
+	if n := CompactTo(&dst, a); n != 3 {
+		t.Errorf("CompactTo(a) = %d, want 3", n)
+	}
+	if n := CompactTo(&dst, b); n != 3 {
+		t.Errorf("CompactTo(b) = %d, want 3", n)
+	}
+	want := []*Entity{a[0], a[2], a[4], b[0], b[2], b[4]}
+	if !slices.Equal(dst, want) {
+		t.Errorf("dst = %v, want %v", dst, want)
+	}
+	if len(a) != 5 || len(b) != 5 {
+		t.Error("CompactTo modified src")
+	}
+}
+
+func TestTryKill(t *testing.T) {
+	for range 100 {
+		var e EntityAtomic
+		var wins atomic.Int32
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if e.TryKill() {
+					wins.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+		if n := wins.Load(); n != 1 {
+			t.Fatalf("%d goroutines won TryKill, want 1", n)
+		}
+		if !e.TryRevive() || e.TryRevive() {
+			t.Fatal("TryRevive must succeed exactly once on a dead entity")
+		}
+	}
+
+	var e Entity
+	if !e.TryKill() || e.TryKill() {
+		t.Error("Entity.TryKill must succeed exactly once")
+	}
+}
+
+func TestSweepEachMapMutate(t *testing.T) {
+	m := map[int]*Entity{1: {}, 2: {}, 3: {dead: true}}
+	visited := map[int]int{}
+	SweepEachMap(m, func(k int, _ *Entity) {
+		visited[k]++
+		if k == 1 {
+			m[10] = &Entity{} // inserted: not visited in this pass
+			delete(m, 2)      // deleted: skipped if not yet visited
+		}
+	})
+	if visited[10] != 0 || visited[1] != 1 || visited[2] > 1 {
+		t.Errorf("visited = %v", visited)
+	}
+	if len(m) != 2 || m[1] == nil || m[10] == nil {
+		t.Errorf("m = %v, want keys 1 and 10", m)
+	}
+}
+
+func TestSweepEmpty(t *testing.T) {
+	type S = []*Entity
+	never := func(int, *Entity) { t.Error("pred called") }
+	sweeps := map[string]func(*S){
+		"Sweep":            func(xs *S) { Sweep(xs) },
+		"SweepStable":      func(xs *S) { SweepStable(xs) },
+		"SweepN":           func(xs *S) { SweepN(xs) },
+		"SweepUnordered":   func(xs *S) { SweepUnordered(xs) },
+		"SweepEach":        func(xs *S) { SweepEach(xs, never) },
+		"SweepEachNew":     func(xs *S) { SweepEachNew(xs, never) },
+		"SweepEachReverse": func(xs *S) { SweepEachReverse(xs, never) },
+		"SweepEach2":       func(xs *S) { SweepEach2(xs, func(int, int, *Entity) { t.Error("pred called") }) },
+		"SweepChunked":     func(xs *S) { SweepChunked(xs, 2, func(int, int) { t.Error("onChunk called") }) },
+		"SweepIndices": func(xs *S) {
+			if got := SweepIndices(xs); got != nil {
+				t.Errorf("SweepIndices() = %v", got)
+			}
+		},
+		"SweepMoved": func(xs *S) { SweepMoved(xs, func(int, int, *Entity) { t.Error("onMove called") }) },
+		"SweepEachErr": func(xs *S) {
+			if err := SweepEachErr(xs, func(int, *Entity) error { return errors.New("called") }); err != nil {
+				t.Errorf("SweepEachErr() = %v", err)
+			}
+		},
+		"SweepSorted": func(xs *S) { SweepSorted(xs, func(a, b *Entity) bool { return false }) },
+		"SweepShrink": func(xs *S) { SweepShrink(xs) },
+		"SweepZip":    func(xs *S) { SweepZip[*Entity, S, int, []int](xs, nil) },
+		"Clear":       func(xs *S) { Clear(xs) },
+	}
+	for name, sweep := range sweeps {
+		sweep(nil)
+		xs := S{}
+		sweep(&xs)
+		if xs == nil || len(xs) != 0 {
+			t.Errorf("%s: empty slice became %#v", name, xs)
+		}
+	}
+
+	var ps []payload
+	SweepPtr(&ps)
+	SweepPtr[payload, *payload, []payload](nil)
+	SweepEachPtr[payload, *payload, []payload](nil, func(int, *payload) { t.Error("pred called") })
+	TickSweep[*TimedEntity, []*TimedEntity](nil)
+	KillAllMap(map[int]*Entity(nil))
+}
+
+func TestSweepSwap(t *testing.T) {
+	xs := []*payload{{v: 0}, {v: 1}, {v: 2}, {v: 3}, {v: 4}}
+	side := []string{"0", "1", "2", "3", "4"}
+	xs[0].Kill()
+	xs[3].Kill()
+	SweepSwap(&xs, func(i, j int) { side[i], side[j] = side[j], side[i] })
+	side = side[:len(xs)]
+	if !slices.Equal(side, []string{"1", "2", "4"}) {
+		t.Errorf("side = %v, want [1 2 4]", side)
+	}
+	for i, x := range xs {
+		if side[i] != strconv.Itoa(x.v) {
+			t.Errorf("xs[%d].v = %d, side[%d] = %s", i, x.v, i, side[i])
+		}
+	}
+}
+
+func TestCompactMasked(t *testing.T) {
+	xs := mixed()
+	ys := slices.Clone(xs)
+	side := []int{0, 1, 2, 3, 4}
+	keep := MarkDead(xs, nil)
+	CompactMasked(&xs, keep)
+	CompactMasked(&side, keep)
+	Sweep(&ys)
+	if !slices.Equal(xs, ys) {
+		t.Errorf("MarkDead and CompactMasked = %v, Sweep = %v", xs, ys)
+	}
+	if !slices.Equal(side, []int{0, 2, 4}) {
+		t.Errorf("side = %v, want [0 2 4]", side)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("no panic on a mask length mismatch")
+		}
+	}()
+	CompactMasked(&side, keep)
+}
+
+func TestCompactMaskedDispose(t *testing.T) {
+	xs := []*disposed{{}, {}, {}}
+	xs[1].Kill()
+	b := xs[1]
+	CompactMasked(&xs, MarkDead(xs, nil))
+	Sweep(&xs)
+	if b.n != 1 || xs[0].n != 0 || xs[1].n != 0 {
+		t.Errorf("disposed %d, %d, %d times, want 0, 1, 0", xs[0].n, b.n, xs[1].n)
+	}
+}
+
+func TestSweepEach2(t *testing.T) {
+	xs := newEntities(4)
+	xs[0].Kill()
+	xs[2].Kill()
+	type pair struct{ old, new int }
+	var got []pair
+	SweepEach2(&xs, func(o, n int, x *Entity) {
+		if xs[n] != x {
+			t.Errorf("xs[%d] != x", n)
+		}
+		got = append(got, pair{o, n})
+	})
+	if !slices.Equal(got, []pair{{1, 0}, {3, 1}}) {
+		t.Errorf("pred called with %v, want [{1 0} {3 1}]", got)
+	}
+}
+
+func TestRevive(t *testing.T) {
+	xs := newEntities(2)
+	xs[0].Kill()
+	xs[0].Revive()
+	Sweep(&xs)
+	if len(xs) != 2 {
+		t.Errorf("len(xs) = %d, want 2: an entity revived before the sweep must be kept", len(xs))
+	}
+
+	var a EntityAtomic
+	a.Kill()
+	a.Revive()
+	if !a.Alive() {
+		t.Error("EntityAtomic.Revive did not revive")
+	}
+}
+
+func TestSweepZeroes(t *testing.T) {
+	xs := newEntities(4)
+	xs[0].Kill()
+	xs[2].Kill()
+	Sweep(&xs)
+	for i, x := range xs[:4][2:] {
+		if x != nil {
+			t.Errorf("vacated slot %d not zeroed", i+2)
+		}
+	}
+
+	ys := []*pooled{{}, {}}
+	ys[0].Kill()
+	var pool Pool[*pooled]
+	SweepPool(&ys, &pool)
+	if ys[:2][1] != nil {
+		t.Error("SweepPool did not zero the vacated slot")
+	}
+}
+
+func TestSweepUnordered(t *testing.T) {
+	xs := mixed()
+	want := []*Entity{xs[0], xs[2], xs[4]}
+	SweepUnordered(&xs)
+	if len(xs) != 3 {
+		t.Fatalf("len(xs) = %d, want 3", len(xs))
+	}
+	for _, w := range want {
+		if !slices.Contains(xs, w) {
+			t.Errorf("survivor %p missing from %v", w, xs)
+		}
+	}
+	if xs[:5][3] != nil || xs[:5][4] != nil {
+		t.Error("vacated slots not zeroed")
+	}
+}
+
+func BenchmarkSweepUnordered(b *testing.B) {
+	all := newEntities(1 << 12)
+	xs := make([]*Entity, 0, len(all))
+	for i := range b.N {
+		xs = append(xs[:0], all...)
+		churn(xs, i)
+		SweepUnordered(&xs)
+		for _, x := range all {
+			x.dead = false
+		}
+	}
+}
+
+// BenchmarkSweepSparse and BenchmarkSweepUnorderedSparse kill one element
+// in 64, the case SweepUnordered is meant for.
+func BenchmarkSweepSparse(b *testing.B) {
+	benchmarkSparse(b, Sweep[*Entity, []*Entity])
+}
+
+func BenchmarkSweepUnorderedSparse(b *testing.B) {
+	benchmarkSparse(b, SweepUnordered[*Entity, []*Entity])
+}
+
+func benchmarkSparse(b *testing.B, sweep func(*[]*Entity)) {
+	all := newEntities(1 << 12)
+	xs := make([]*Entity, 0, len(all))
+	for i := range b.N {
+		xs = append(xs[:0], all...)
+		for j := i % 64; j < len(xs); j += 64 {
+			xs[j].dead = true
+		}
+		sweep(&xs)
+		for _, x := range all {
+			x.dead = false
+		}
+	}
+}
+
+func TestSweepEachN(t *testing.T) {
+	xs := mixed()
+	calls := 0
+	if n := SweepEachN(&xs, func(int, *Entity) { calls++ }); n != 2 || calls != 3 {
+		t.Errorf("SweepEachN() = %d with %d calls, want 2 with 3", n, calls)
+	}
+	m := map[int]*Entity{1: {}, 2: {dead: true}}
+	calls = 0
+	if n := SweepEachMapN(m, func(int, *Entity) { calls++ }); n != 1 || calls != 1 || len(m) != 1 {
+		t.Errorf("SweepEachMapN() = %d with %d calls, len = %d, want 1, 1, 1", n, calls, len(m))
+	}
+}
+
+func TestSweepDrain(t *testing.T) {
+	xs := []*disposed{{}, {}, {}, {}}
+	xs[0].Kill()
+	xs[2].Kill()
+	a, c := xs[0], xs[2]
+	dst := []*disposed{{}} // appended to, not replaced
+	SweepDrain(&xs, &dst)
+	if len(xs) != 2 {
+		t.Errorf("len(xs) = %d, want 2", len(xs))
+	}
+	if len(dst) != 3 || dst[1] != a || dst[2] != c {
+		t.Errorf("dst = %v, want [_ a c]", dst)
+	}
+	if a.n != 0 || c.n != 0 {
+		t.Error("SweepDrain disposed the drained entities")
+	}
+}
+
+func TestDispose(t *testing.T) {
+	xs := []*disposed{{}, {}}
+	xs[0].Kill()
+	a := xs[0]
+	Sweep(&xs)
+	Sweep(&xs)
+	if a.n != 1 || xs[0].n != 0 {
+		t.Errorf("Sweep disposed the removed entity %d times and the survivor %d times, want 1, 0", a.n, xs[0].n)
+	}
+
+	m := map[int]*disposed{1: {Entity: Entity{dead: true}}}
+	b := m[1]
+	SweepMap(m)
+	if b.n != 1 {
+		t.Errorf("SweepMap disposed the removed entity %d times, want 1", b.n)
+	}
+
+}
+
+func TestSweepRemap(t *testing.T) {
+	xs := mixed()
+	buf := make([]int, 0, 8)
+	remap := SweepRemap(&xs, buf)
+	if !slices.Equal(remap, []int{0, -1, 1, -1, 2}) {
+		t.Errorf("SweepRemap() = %v, want [0 -1 1 -1 2]", remap)
+	}
+	if &remap[0] != &buf[:1][0] {
+		t.Error("SweepRemap did not reuse the capacity of remap")
+	}
+	if got := SweepRemap[*Entity, []*Entity](nil, remap); len(got) != 0 {
+		t.Errorf("SweepRemap(nil) = %v, want empty", got)
+	}
+}
+
+func TestSweepEachMapErr(t *testing.T) {
+	m := map[int]*Entity{}
+	for k := range 10 {
+		m[k] = &Entity{dead: k%2 == 0}
+	}
+	errStop := errors.New("stop")
+	calls := 0
+	err := SweepEachMapErr(m, func(int, *Entity) error {
+		calls++
+		return errStop
+	})
+	if err != errStop || calls != 1 {
+		t.Errorf("SweepEachMapErr() = %v after %d calls, want %v after 1", err, calls, errStop)
+	}
+	if len(m) != 5 {
+		t.Errorf("len(m) = %d, want 5: the sweep must finish after an error", len(m))
+	}
+}
+
+func TestSweepSet(t *testing.T) {
+	a, b := &disposed{}, &disposed{}
+	b.Kill()
+	s := map[*disposed]struct{}{a: {}, b: {}}
+	if n := SweepSet(s); n != 1 || len(s) != 1 || b.n != 1 {
+		t.Errorf("SweepSet() = %d, len = %d, disposed %d times, want 1, 1, 1", n, len(s), b.n)
+	}
+	if _, ok := s[a]; !ok {
+		t.Error("live element deleted")
+	}
+}
+
+func TestSweepShrinkFrac(t *testing.T) {
+	for _, tt := range []struct {
+		frac  float64
+		alloc bool
+	}{
+		{0, false},
+		{0.4, false},
+		{0.8, true},
+		{1, true},
+	} {
+		xs := make([]*Entity, 10, 16)
+		for i := range xs {
+			xs[i] = &Entity{dead: i >= 7}
+		}
+		p := &xs[0]
+		SweepShrinkFrac(&xs, tt.frac)
+		if len(xs) != 7 || (&xs[0] != p) != tt.alloc {
+			t.Errorf("frac %v: len = %d, reallocated = %v, want 7, %v", tt.frac, len(xs), &xs[0] != p, tt.alloc)
+		}
+	}
+}
+
+func TestSweepNil(t *testing.T) {
+	a, b := &disposed{}, &disposed{}
+	b.Kill()
+	xs := []*disposed{nil, a, nil, b}
+	SweepNil(&xs)
+	if !slices.Equal(xs, []*disposed{a}) {
+		t.Errorf("xs = %v, want [a]", xs)
+	}
+	if b.n != 1 {
+		t.Errorf("dead element disposed %d times, want 1", b.n)
+	}
+	SweepNil[disposed, *disposed, []*disposed](nil)
+}
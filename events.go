@@ -0,0 +1,85 @@
+package ei
+
+// Event is a kind of lifecycle notification delivered by Events.
+type Event int
+
+const (
+	Spawned Event = iota // the entity was added to the collection
+	Killed               // the entity went from alive to dead
+	Swept                // the entity was removed from the collection
+)
+
+// Events delivers lifecycle notifications for a collection to subscribers,
+// so that code such as audio or achievements need not live in the update
+// loop. Notifications are only sent for spawns, kills and sweeps made
+// through the Events methods.
+//
+//	ev := ei.NewEvents(&enemies)
+//	ev.Subscribe(ei.Killed, func(e *Enemy) { playSound(e.DeathSound) })
+//	ev.Spawn(NewEnemy())
+//	...
+//	ev.Kill(enemy)
+//	ev.Sweep()
+type Events[E Interface, S ~[]E] struct {
+	xs   *S
+	subs [Swept + 1][]func(E)
+}
+
+// NewEvents returns an Events for the collection *xs.
+func NewEvents[E Interface, S ~[]E](xs *S) *Events[E, S] {
+	return &Events[E, S]{xs: xs}
+}
+
+// Subscribe registers f to be called with the entity whenever event is
+// sent. Subscribers are called in registration order.
+func (ev *Events[E, S]) Subscribe(event Event, f func(E)) {
+	ev.subs[event] = append(ev.subs[event], f)
+}
+
+// Emit calls the subscribers of event with e.
+func (ev *Events[E, S]) Emit(event Event, e E) {
+	for _, f := range ev.subs[event] {
+		f(e)
+	}
+}
+
+// Spawn appends e to the collection and sends Spawned.
+func (ev *Events[E, S]) Spawn(e E) {
+	*ev.xs = append(*ev.xs, e)
+	ev.Emit(Spawned, e)
+}
+
+// Kill kills e and sends Killed if it was alive.
+func (ev *Events[E, S]) Kill(e E) {
+	if !e.Alive() {
+		return
+	}
+	e.Kill()
+	if !e.Alive() {
+		ev.Emit(Killed, e)
+	}
+}
+
+// Sweep removes dead elements from the collection like SweepN, sending
+// Swept for each of them before it is disposed, and returns the number
+// removed.
+func (ev *Events[E, S]) Sweep() int {
+	xs := ev.xs
+	if xs == nil {
+		return 0
+	}
+	j := 0
+	for _, x := range *xs {
+		if x.Alive() {
+			(*xs)[j] = x
+			j++
+		} else {
+			ev.Emit(Swept, x)
+			dispose(x)
+		}
+	}
+	n := len(*xs) - j
+	clear((*xs)[j:])
+	*xs = (*xs)[:j]
+	return n
+}
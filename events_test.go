@@ -0,0 +1,55 @@
+package ei
+
+import (
+	"slices"
+	"testing"
+)
+
+type disposed struct {
+	Entity
+	n int
+}
+
+func (d *disposed) Dispose() { d.n++ }
+
+type disposedList []*disposed
+
+func TestEvents(t *testing.T) {
+	var xs disposedList
+	ev := NewEvents(&xs)
+	var got []Event
+	for _, event := range []Event{Spawned, Killed, Swept} {
+		ev.Subscribe(event, func(e *disposed) {
+			if event == Swept && e.n != 0 {
+				t.Error("Swept sent after Dispose")
+			}
+			got = append(got, event)
+		})
+	}
+
+	a, b := &disposed{}, &disposed{}
+	ev.Spawn(a)
+	ev.Spawn(b)
+	ev.Kill(a)
+	ev.Kill(a) // already dead: no notification
+	if n := ev.Sweep(); n != 1 {
+		t.Errorf("Sweep() = %d, want 1", n)
+	}
+	want := []Event{Spawned, Spawned, Killed, Swept}
+	if !slices.Equal(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+	if len(xs) != 1 || xs[0] != b || a.n != 1 {
+		t.Errorf("after Sweep: len = %d, disposed %d times", len(xs), a.n)
+	}
+}
+
+func TestEventsSweeper(t *testing.T) {
+	xs := []*Entity{{}, {}}
+	var w World
+	w.Register("xs", NewEvents(&xs))
+	xs[0].Kill()
+	if n := w.Sweep(); n != 1 || len(xs) != 1 {
+		t.Errorf("World.Sweep() = %d, len = %d", n, len(xs))
+	}
+}
@@ -0,0 +1,23 @@
+package ei
+
+// Func adapts a value that cannot embed Entity, such as a third-party type,
+// to Interface. The value is alive until it is killed or alive reports
+// false.
+//
+// Each Alive call goes through the alive function, which costs an indirect
+// call compared to an embedded Entity. The methods have pointer receivers,
+// so sweep a []*Func[E] with Sweep or a []Func[E] with SweepPtr.
+type Func[E any] struct {
+	V     E
+	alive func(E) bool
+	dead  bool
+}
+
+// Wrap returns a Func holding v whose liveness is reported by alive.
+func Wrap[E any](v E, alive func(E) bool) Func[E] {
+	return Func[E]{V: v, alive: alive}
+}
+
+func (f *Func[E]) Kill() { f.dead = true }
+
+func (f *Func[E]) Alive() bool { return !f.dead && f.alive(f.V) }
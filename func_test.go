@@ -0,0 +1,16 @@
+package ei
+
+import "testing"
+
+func TestFunc(t *testing.T) {
+	type handle struct{ closed bool }
+	open := func(h *handle) bool { return !h.closed }
+	a, b, c := &handle{}, &handle{}, &handle{}
+	xs := []Func[*handle]{Wrap(a, open), Wrap(b, open), Wrap(c, open)}
+	b.closed = true
+	xs[2].Kill()
+	SweepPtr(&xs)
+	if len(xs) != 1 || xs[0].V != a {
+		t.Errorf("xs = %v, want [a]", xs)
+	}
+}
@@ -1,6 +1,6 @@
 module github.com/eihigh/ei
 
-go 1.19
+go 1.24
 
 require golang.org/x/tools v0.4.0
 
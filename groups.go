@@ -0,0 +1,61 @@
+package ei
+
+import "iter"
+
+// Groups holds entities tagged with any number of names, such as "enemy" or
+// "bullet", and keeps the per-tag indexes consistent through Sweep.
+type Groups[E Interface] struct {
+	all  []E
+	tags map[string][]E
+}
+
+// Add adds e under the given tags.
+func (g *Groups[E]) Add(e E, tags ...string) {
+	if g.tags == nil {
+		g.tags = map[string][]E{}
+	}
+	g.all = append(g.all, e)
+	for _, tag := range tags {
+		g.tags[tag] = append(g.tags[tag], e)
+	}
+}
+
+// Group returns an iterator over the live entities tagged with tag, in the
+// order they were added.
+func (g *Groups[E]) Group(tag string) iter.Seq[E] {
+	return Filter(g.tags[tag])
+}
+
+// All returns an iterator over all live entities, in the order they were
+// added.
+func (g *Groups[E]) All() iter.Seq[E] {
+	return Filter(g.all)
+}
+
+// KillGroup kills every live entity tagged with tag and returns how many
+// were killed. They are removed on the next Sweep.
+func (g *Groups[E]) KillGroup(tag string) int {
+	return KillWhere(g.tags[tag], func(E) bool { return true })
+}
+
+// Sweep removes dead entities from every group and returns the number of
+// distinct entities removed. Tags left without entities are forgotten.
+func (g *Groups[E]) Sweep() int {
+	n := SweepN(&g.all)
+	for tag, members := range g.tags {
+		j := 0
+		for _, x := range members {
+			if x.Alive() {
+				members[j] = x
+				j++
+			}
+		}
+		clear(members[j:])
+		if j == 0 {
+			delete(g.tags, tag)
+		} else {
+			g.tags[tag] = members[:j]
+		}
+	}
+	return n
+}
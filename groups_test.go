@@ -0,0 +1,29 @@
+package ei
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGroups(t *testing.T) {
+	var g Groups[*disposed]
+	a, b, c := &disposed{}, &disposed{}, &disposed{}
+	g.Add(a, "enemy", "boss")
+	g.Add(b, "enemy")
+	g.Add(c, "bullet")
+	if n := g.KillGroup("boss"); n != 1 || a.Alive() {
+		t.Errorf("KillGroup(boss) = %d, a alive = %v", n, a.Alive())
+	}
+	if got := slices.Collect(g.Group("enemy")); !slices.Equal(got, []*disposed{b}) {
+		t.Errorf("Group(enemy) = %v, want [b]", got)
+	}
+	if n := g.Sweep(); n != 1 || a.n != 1 {
+		t.Errorf("Sweep() = %d, a disposed %d times, want 1, 1", n, a.n)
+	}
+	if _, ok := g.tags["boss"]; ok {
+		t.Error("empty tag not forgotten")
+	}
+	if got := slices.Collect(g.All()); !slices.Equal(got, []*disposed{b, c}) {
+		t.Errorf("All() = %v, want [b c]", got)
+	}
+}
@@ -0,0 +1,45 @@
+package ei
+
+import "sync/atomic"
+
+// Lifecycle hooks for instrumentation. They are not synchronized, so set
+// them before entities are used from multiple goroutines.
+var (
+	// OnSpawn, if non-nil, is called by NewEntity and the constructors built
+	// on it, such as NewTimed.
+	OnSpawn func()
+
+	// OnKill, if non-nil, is called with the *Entity or *EntityAtomic when
+	// it goes from alive to dead. Killing a dead entity does not call it.
+	OnKill func(any)
+)
+
+// NewEntity returns an alive entity, calling OnSpawn. The zero Entity is
+// equally valid; NewEntity only exists so that spawns can be observed.
+func NewEntity() Entity {
+	spawnCount.Add(1)
+	if OnSpawn != nil {
+		OnSpawn()
+	}
+	return Entity{}
+}
+
+var spawnCount, killCount atomic.Uint64
+
+// Stats returns the number of entities spawned by NewEntity or revived by
+// TryRevive or Reset, the number of alive-to-dead transitions, and their
+// difference. Entities created without NewEntity are not counted as spawned,
+// in which case alive is clamped at zero.
+func Stats() (spawned, killed, alive uint64) {
+	spawned, killed = spawnCount.Load(), killCount.Load()
+	if spawned > killed {
+		alive = spawned - killed
+	}
+	return spawned, killed, alive
+}
+
+// ResetStats zeroes the counters reported by Stats. It is intended for tests.
+func ResetStats() {
+	spawnCount.Store(0)
+	killCount.Store(0)
+}
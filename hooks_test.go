@@ -0,0 +1,70 @@
+package ei
+
+import "testing"
+
+// setHooks installs spawn and kill hooks for the duration of the test.
+func setHooks(t *testing.T, spawn func(), kill func(any)) {
+	oldSpawn, oldKill := OnSpawn, OnKill
+	OnSpawn, OnKill = spawn, kill
+	t.Cleanup(func() { OnSpawn, OnKill = oldSpawn, oldKill })
+}
+
+func TestHooks(t *testing.T) {
+	var spawns int
+	var kills []any
+	setHooks(t, func() { spawns++ }, func(e any) { kills = append(kills, e) })
+
+	e := NewEntity()
+	a := &EntityAtomic{}
+	_ = NewEntity()
+	e.Kill()
+	e.Kill()
+	a.Kill()
+	a.Kill()
+	if spawns != 2 {
+		t.Errorf("OnSpawn called %d times, want 2", spawns)
+	}
+	if len(kills) != 2 || kills[0] != &e || kills[1] != a {
+		t.Errorf("OnKill called with %v, want [&e a]", kills)
+	}
+}
+
+func TestStats(t *testing.T) {
+	ResetStats()
+	t.Cleanup(ResetStats)
+	a, b := NewEntity(), NewEntity()
+	a.Kill()
+	a.Kill()
+	if s, k, n := Stats(); s != 2 || k != 1 || n != 1 {
+		t.Errorf("Stats() = %d, %d, %d, want 2, 1, 1", s, k, n)
+	}
+	a.TryRevive()
+	b.Kill()
+	var c Entity // not spawned by NewEntity
+	c.Kill()
+	if s, k, n := Stats(); s != 3 || k != 3 || n != 0 {
+		t.Errorf("Stats() = %d, %d, %d, want 3, 3, 0", s, k, n)
+	}
+	var d Entity
+	d.Kill()
+	if _, k, n := Stats(); k != 4 || n != 0 {
+		t.Errorf("Stats() with more kills than spawns: killed = %d, alive = %d, want 4, 0", k, n)
+	}
+}
+
+func TestStatsConstructors(t *testing.T) {
+	ResetStats()
+	t.Cleanup(ResetStats)
+	var spawns int
+	setHooks(t, func() { spawns++ }, nil)
+	timed := NewTimed(1)
+	ident := NewIdentified()
+	if spawns != 2 {
+		t.Errorf("OnSpawn called %d times, want 2", spawns)
+	}
+	timed.Tick()
+	ident.Kill()
+	if s, k, n := Stats(); s != 2 || k != 2 || n != 0 {
+		t.Errorf("Stats() = %d, %d, %d, want 2, 2, 0", s, k, n)
+	}
+}
@@ -0,0 +1,78 @@
+package ei
+
+import "sync/atomic"
+
+var lastID atomic.Uint64
+
+// IdentifiedEntity is an Entity with a process-unique ID.
+type IdentifiedEntity struct {
+	Entity
+	id uint64
+}
+
+// NewIdentified returns an alive entity with a new ID. IDs start at 1 and
+// are never reused, even after the entity is swept. Like NewEntity, it calls
+// OnSpawn.
+func NewIdentified() IdentifiedEntity {
+	return IdentifiedEntity{Entity: NewEntity(), id: lastID.Add(1)}
+}
+
+func (e *IdentifiedEntity) ID() uint64 { return e.id }
+
+// ResetIDs restarts ID assignment from 1. It is intended for tests only;
+// IDs handed out afterwards collide with earlier ones.
+func ResetIDs() { lastID.Store(0) }
+
+// Identified is implemented by entities with a stable ID, such as
+// IdentifiedEntity.
+type Identified interface {
+	ID() uint64
+}
+
+// IndexMap is a slice of entities with O(1) lookup by ID that stays valid as
+// sweeps move the entities around.
+type IndexMap[E interface {
+	Interface
+	Identified
+}] struct {
+	items []E
+	index map[uint64]int // key=ID, value=index into items
+}
+
+// Add appends e. Adding two entities with the same ID is not supported.
+func (m *IndexMap[E]) Add(e E) {
+	if m.index == nil {
+		m.index = map[uint64]int{}
+	}
+	m.index[e.ID()] = len(m.items)
+	m.items = append(m.items, e)
+}
+
+// Sweep removes dead entities, updates the index of moved survivors and
+// returns the number removed.
+func (m *IndexMap[E]) Sweep() int {
+	n := len(m.items)
+	for _, x := range m.items {
+		if !x.Alive() {
+			delete(m.index, x.ID())
+		}
+	}
+	SweepMoved(&m.items, func(_, newIndex int, x E) {
+		m.index[x.ID()] = newIndex
+	})
+	return n - len(m.items)
+}
+
+// ByID returns the live entity with the given ID.
+func (m *IndexMap[E]) ByID(id uint64) (E, bool) {
+	i, ok := m.index[id]
+	if !ok || !m.items[i].Alive() {
+		var zero E
+		return zero, false
+	}
+	return m.items[i], true
+}
+
+// Items returns the entities in insertion order, including dead ones not yet
+// swept. The slice is valid until the next Add or Sweep.
+func (m *IndexMap[E]) Items() []E { return m.items }
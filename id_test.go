@@ -0,0 +1,42 @@
+package ei
+
+import "testing"
+
+func TestIdentified(t *testing.T) {
+	ResetIDs()
+	t.Cleanup(ResetIDs)
+	a, b := NewIdentified(), NewIdentified()
+	if a.ID() != 1 || b.ID() != 2 {
+		t.Errorf("IDs = %d, %d, want 1, 2", a.ID(), b.ID())
+	}
+	a.Kill()
+	if c := NewIdentified(); c.ID() != 3 {
+		t.Errorf("ID after a kill = %d, want 3", c.ID())
+	}
+}
+
+func TestIndexMap(t *testing.T) {
+	var m IndexMap[*IdentifiedEntity]
+	var xs []*IdentifiedEntity
+	for range 5 {
+		e := NewIdentified()
+		xs = append(xs, &e)
+		m.Add(&e)
+	}
+	xs[1].Kill()
+	if _, ok := m.ByID(xs[1].ID()); ok {
+		t.Error("ByID found a dead entity before Sweep")
+	}
+	if n := m.Sweep(); n != 1 {
+		t.Errorf("Sweep() = %d, want 1", n)
+	}
+	for i, x := range xs {
+		got, ok := m.ByID(x.ID())
+		if want := i != 1; ok != want || (ok && got != x) {
+			t.Errorf("ByID(xs[%d]) = %p, %v, want %p, %v", i, got, ok, x, want)
+		}
+	}
+	if len(m.Items()) != 4 {
+		t.Errorf("len(Items()) = %d, want 4", len(m.Items()))
+	}
+}
@@ -0,0 +1,59 @@
+package ei
+
+// IDAllocator hands out handles whose indices are small and dense: the
+// index of a freed handle is reused by a later Alloc with a new generation,
+// so stale handles are told apart from the new one. Callers that only need
+// a compact ID can use Handle.Index and ignore the generation.
+//
+// To recycle the IDs of swept entities, free them from a Dispose method:
+//
+//	func (e *Enemy) Dispose() { ids.Free(e.handle) }
+type IDAllocator struct {
+	gens []uint32 // generation per index; starts at 1 and changes on Free
+	used []bool
+	free []uint32 // unused indices
+	n    int      // number of used indices
+}
+
+// Alloc returns a new handle.
+func (a *IDAllocator) Alloc() Handle {
+	var i uint32
+	if n := len(a.free); n > 0 {
+		i = a.free[n-1]
+		a.free = a.free[:n-1]
+	} else {
+		i = uint32(len(a.gens))
+		a.gens = append(a.gens, 1)
+		a.used = append(a.used, false)
+	}
+	a.used[i] = true
+	a.n++
+	return Handle{i, a.gens[i]}
+}
+
+// Free releases h so that its index can be reused. It reports false, doing
+// nothing, if h is not valid.
+func (a *IDAllocator) Free(h Handle) bool {
+	if !a.Valid(h) {
+		return false
+	}
+	a.used[h.index] = false
+	a.gens[h.index]++
+	if a.gens[h.index] == 0 {
+		a.gens[h.index] = 1
+	}
+	a.free = append(a.free, h.index)
+	a.n--
+	return true
+}
+
+// Valid reports whether h was returned by Alloc and has not been freed.
+func (a *IDAllocator) Valid(h Handle) bool {
+	return int(h.index) < len(a.gens) && a.used[h.index] && a.gens[h.index] == h.generation
+}
+
+// Len returns the number of allocated handles.
+func (a *IDAllocator) Len() int { return a.n }
+
+// handle returns the current handle of index i, valid or not.
+func (a *IDAllocator) handle(i int) Handle { return Handle{uint32(i), a.gens[i]} }
@@ -0,0 +1,34 @@
+package ei
+
+import "testing"
+
+func TestIDAllocator(t *testing.T) {
+	var a IDAllocator
+	h0, h1 := a.Alloc(), a.Alloc()
+	if h0.Index() != 0 || h1.Index() != 1 || a.Len() != 2 {
+		t.Fatalf("Alloc() = %v, %v, Len() = %d", h0, h1, a.Len())
+	}
+	if !a.Free(h0) || a.Free(h0) {
+		t.Error("Free must succeed exactly once")
+	}
+	if a.Valid(h0) || !a.Valid(h1) || a.Valid(Handle{}) {
+		t.Error("Valid() disagrees with the allocations")
+	}
+	h2 := a.Alloc()
+	if h2.Index() != 0 || h2 == h0 {
+		t.Errorf("Alloc() after Free = %v, want index 0 with a new generation", h2)
+	}
+	if a.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", a.Len())
+	}
+}
+
+func TestIDAllocatorGenerationWrap(t *testing.T) {
+	var a IDAllocator
+	a.Alloc()
+	a.gens[0] = ^uint32(0)
+	a.Free(a.handle(0))
+	if g := a.Alloc().Generation(); g != 1 {
+		t.Errorf("generation after wrapping = %d, want 1: generation 0 is reserved for the zero Handle", g)
+	}
+}
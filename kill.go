@@ -0,0 +1,101 @@
+package ei
+
+// KillWhere kills every live element for which pred returns true and
+// returns how many were killed. Nothing is removed; call Sweep afterwards.
+func KillWhere[E Interface, S ~[]E](xs S, pred func(E) bool) int {
+	n := 0
+	for _, x := range xs {
+		if x.Alive() && pred(x) {
+			x.Kill()
+			n++
+		}
+	}
+	return n
+}
+
+// SweepWhere kills every live element for which pred returns true and
+// removes all dead elements in the same pass, returning the number removed.
+// It is equivalent to KillWhere followed by SweepN.
+func SweepWhere[E Interface, S ~[]E](xs *S, pred func(E) bool) int {
+	if xs == nil {
+		return 0
+	}
+	j := 0
+	for _, x := range *xs {
+		if x.Alive() && pred(x) {
+			x.Kill()
+		}
+		if x.Alive() {
+			(*xs)[j] = x
+			j++
+		} else {
+			dispose(x)
+		}
+	}
+	n := len(*xs) - j
+	clear((*xs)[j:])
+	*xs = (*xs)[:j]
+	return n
+}
+
+// KillWhereMap is like KillWhere for maps. Nothing is deleted; call SweepMap
+// afterwards.
+func KillWhereMap[K comparable, V Interface, M ~map[K]V](m M, pred func(K, V) bool) int {
+	n := 0
+	for k, v := range m {
+		if v.Alive() && pred(k, v) {
+			v.Kill()
+			n++
+		}
+	}
+	return n
+}
+
+// KillAll kills every element. Nothing is removed.
+func KillAll[E Interface, S ~[]E](xs S) {
+	for _, x := range xs {
+		x.Kill()
+	}
+}
+
+// KillAllMap kills every value. Nothing is deleted.
+func KillAllMap[K comparable, V Interface, M ~map[K]V](m M) {
+	for _, v := range m {
+		v.Kill()
+	}
+}
+
+// Clear truncates the slice to zero length, keeping its capacity for reuse.
+// The old elements are zeroed so that they can be collected.
+func Clear[E any, S ~[]E](xs *S) {
+	if xs == nil {
+		return
+	}
+	clear(*xs)
+	*xs = (*xs)[:0]
+}
+
+// Parent is implemented by entities that own child entities.
+type Parent interface {
+	Children() []Interface
+}
+
+// KillTree kills e and, recursively, the children of every Parent in the
+// tree. Each node is killed once even if it is reachable more than once or
+// the links form a cycle. Nodes must be comparable, as pointers are.
+func KillTree(e Interface) {
+	killTree(e, map[Interface]struct{}{})
+}
+
+func killTree(e Interface, visited map[Interface]struct{}) {
+	if _, ok := visited[e]; ok {
+		return
+	}
+	visited[e] = struct{}{}
+	e.Kill()
+	if p, ok := e.(Parent); ok {
+		for _, c := range p.Children() {
+			killTree(c, visited)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package ei
+
+import "testing"
+
+func TestKillWhere(t *testing.T) {
+	xs := []*payload{{v: 1}, {v: 2}, {v: 3}, {v: 4}}
+	xs[3].Kill()
+	even := func(p *payload) bool { return p.v%2 == 0 }
+	if n := KillWhere(xs, even); n != 1 {
+		t.Errorf("KillWhere() = %d, want 1", n)
+	}
+	for _, x := range xs[:3] {
+		if x.Alive() == even(x) {
+			t.Errorf("v = %d: Alive() = %v", x.v, x.Alive())
+		}
+	}
+	if len(xs) != 4 {
+		t.Errorf("KillWhere removed elements: len = %d", len(xs))
+	}
+
+	m := map[string]*payload{"a": {v: 1}, "b": {v: 2}}
+	if n := KillWhereMap(m, func(_ string, p *payload) bool { return even(p) }); n != 1 {
+		t.Errorf("KillWhereMap() = %d, want 1", n)
+	}
+	if !m["a"].Alive() || m["b"].Alive() || len(m) != 2 {
+		t.Errorf("after KillWhereMap: a alive = %v, b alive = %v, len = %d", m["a"].Alive(), m["b"].Alive(), len(m))
+	}
+}
+
+func TestKillAll(t *testing.T) {
+	xs := newEntities(3)
+	KillAll(xs)
+	if n := CountAlive(xs); n != 0 {
+		t.Errorf("%d alive after KillAll", n)
+	}
+	m := map[int]*Entity{1: {}, 2: {}}
+	KillAllMap(m)
+	if m[1].Alive() || m[2].Alive() || len(m) != 2 {
+		t.Errorf("after KillAllMap: %v", m)
+	}
+}
+
+func TestClear(t *testing.T) {
+	xs := newEntities(3)
+	Clear(&xs)
+	if len(xs) != 0 || cap(xs) != 3 {
+		t.Errorf("len, cap = %d, %d, want 0, 3", len(xs), cap(xs))
+	}
+	for i, x := range xs[:3] {
+		if x != nil {
+			t.Errorf("xs[%d] not zeroed", i)
+		}
+	}
+	Clear[*Entity, []*Entity](nil)
+}
+
+func TestKillWhereMapNone(t *testing.T) {
+	m := map[string]*Entity{"a": {}, "b": {dead: true}}
+	if n := KillWhereMap(m, func(string, *Entity) bool { return false }); n != 0 {
+		t.Errorf("KillWhereMap() = %d, want 0", n)
+	}
+	if n := KillWhereMap(m, func(string, *Entity) bool { return true }); n != 1 {
+		t.Errorf("KillWhereMap() = %d, want 1: dead values must not be counted", n)
+	}
+	if len(m) != 2 {
+		t.Errorf("KillWhereMap deleted entries: len = %d", len(m))
+	}
+}
+
+// tree is a Parent that counts how often it is killed.
+type tree struct {
+	Entity
+	kids  []Interface
+	kills int
+}
+
+func (n *tree) Kill()                 { n.kills++; n.Entity.Kill() }
+func (n *tree) Children() []Interface { return n.kids }
+
+func TestKillTree(t *testing.T) {
+	leaf1, leaf2, leaf3 := &tree{}, &tree{}, &tree{}
+	mid1 := &tree{kids: []Interface{leaf1, leaf2}}
+	mid2 := &tree{kids: []Interface{leaf2, leaf3}} // leaf2 is shared
+	root := &tree{kids: []Interface{mid1, mid2}}
+	leaf3.kids = []Interface{root} // a cycle
+	other := &tree{}
+
+	KillTree(root)
+	for i, n := range []*tree{root, mid1, mid2, leaf1, leaf2, leaf3} {
+		if n.Alive() || n.kills != 1 {
+			t.Errorf("node %d: alive = %v, killed %d times, want 1", i, n.Alive(), n.kills)
+		}
+	}
+	if !other.Alive() {
+		t.Error("KillTree killed an unrelated node")
+	}
+}
+
+func TestSweepWhere(t *testing.T) {
+	xs := []*payload{{v: 1}, {v: 2}, {v: 3}, {v: 4}}
+	xs[0].Kill()
+	if n := SweepWhere(&xs, func(p *payload) bool { return p.v == 4 }); n != 2 {
+		t.Errorf("SweepWhere() = %d, want 2", n)
+	}
+	if len(xs) != 2 || xs[0].v != 2 || xs[1].v != 3 {
+		t.Errorf("xs = %v, want [2 3]", xs)
+	}
+}
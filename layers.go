@@ -0,0 +1,109 @@
+package ei
+
+import (
+	"iter"
+	"slices"
+)
+
+// Layers holds entities ordered by layer, such as a z value for drawing.
+// Iteration visits lower layers first and, within a layer, entities in the
+// order they were put there.
+type Layers[E interface {
+	Interface
+	comparable
+}] struct {
+	layers map[int][]layerEntry[E]
+	order  []int          // sorted keys of layers
+	cur    map[E]layerPos // current position of each entity
+}
+
+type layerEntry[E any] struct {
+	e   E
+	gen uint32 // matches layerPos.gen unless the entity has moved since
+}
+
+type layerPos struct {
+	z   int
+	gen uint32
+}
+
+// Add puts e on layer z. Adding an entity that is already present moves it
+// like SetLayer.
+func (l *Layers[E]) Add(e E, z int) {
+	if l.layers == nil {
+		l.layers = map[int][]layerEntry[E]{}
+		l.cur = map[E]layerPos{}
+	}
+	pos, ok := l.cur[e]
+	if ok && pos.z == z {
+		return
+	}
+	pos = layerPos{z, pos.gen + 1}
+	l.cur[e] = pos
+	if _, ok := l.layers[z]; !ok {
+		i, _ := slices.BinarySearch(l.order, z)
+		l.order = slices.Insert(l.order, i, z)
+	}
+	l.layers[z] = append(l.layers[z], layerEntry[E]{e, pos.gen})
+}
+
+// SetLayer moves e to the end of layer z in O(1). The entry left on the old
+// layer is skipped by iteration and dropped by the next Sweep.
+func (l *Layers[E]) SetLayer(e E, z int) { l.Add(e, z) }
+
+// Layer returns the layer of e.
+func (l *Layers[E]) Layer(e E) (int, bool) {
+	pos, ok := l.cur[e]
+	return pos.z, ok
+}
+
+// All returns an iterator over the live entities in drawing order.
+func (l *Layers[E]) All() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for _, z := range l.order {
+			for _, x := range l.layers[z] {
+				if l.current(z, x) && x.e.Alive() && !yield(x.e) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (l *Layers[E]) current(z int, x layerEntry[E]) bool {
+	pos := l.cur[x.e]
+	return pos.z == z && pos.gen == x.gen
+}
+
+// Sweep removes dead entities and stale entries of moved entities from
+// every layer, and returns the number of dead entities removed.
+func (l *Layers[E]) Sweep() int {
+	n := 0
+	order := l.order[:0]
+	for _, z := range l.order {
+		entries := l.layers[z]
+		j := 0
+		for _, x := range entries {
+			if !l.current(z, x) {
+				continue
+			}
+			if !x.e.Alive() {
+				delete(l.cur, x.e)
+				dispose(x.e)
+				n++
+				continue
+			}
+			entries[j] = x
+			j++
+		}
+		clear(entries[j:])
+		if j == 0 {
+			delete(l.layers, z)
+		} else {
+			l.layers[z] = entries[:j]
+			order = append(order, z)
+		}
+	}
+	l.order = order
+	return n
+}
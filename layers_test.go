@@ -0,0 +1,39 @@
+package ei
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLayers(t *testing.T) {
+	var l Layers[*disposed]
+	a, b, c := &disposed{}, &disposed{}, &disposed{}
+	l.Add(a, 1)
+	l.Add(b, 0)
+	l.Add(c, 1)
+	if got := slices.Collect(l.All()); !slices.Equal(got, []*disposed{b, a, c}) {
+		t.Errorf("All() = %v, want [b a c]", got)
+	}
+
+	l.SetLayer(a, 1) // same layer: no change
+	l.SetLayer(b, 1)
+	l.SetLayer(a, 2)
+	l.SetLayer(a, 1) // back to the end of layer 1
+	if got := slices.Collect(l.All()); !slices.Equal(got, []*disposed{c, b, a}) {
+		t.Errorf("All() after SetLayer = %v, want [c b a]", got)
+	}
+	if z, ok := l.Layer(a); !ok || z != 1 {
+		t.Errorf("Layer(a) = %d, %v, want 1, true", z, ok)
+	}
+
+	c.Kill()
+	if n := l.Sweep(); n != 1 || c.n != 1 {
+		t.Errorf("Sweep() = %d, c disposed %d times, want 1, 1", n, c.n)
+	}
+	if !slices.Equal(l.order, []int{1}) || len(l.layers[1]) != 2 {
+		t.Errorf("after Sweep: order = %v, layer 1 = %v", l.order, l.layers[1])
+	}
+	if _, ok := l.Layer(c); ok {
+		t.Error("Layer() found a swept entity")
+	}
+}
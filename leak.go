@@ -0,0 +1,68 @@
+package ei
+
+// LeakDetector finds entities that stay dead for many frames without being
+// swept, which usually means a collection is never swept. It is meant for
+// debugging and costs a map lookup per observed entity.
+//
+//	leaks := ei.LeakDetector[*Enemy]{Frames: 60}
+//	...
+//	leaks.Observe(enemies...) // once per frame, before sweeping
+//	for _, e := range leaks.Next() {
+//		log.Printf("enemy %p dead for 60 frames", e)
+//	}
+type LeakDetector[E interface {
+	Interface
+	comparable
+}] struct {
+	// Frames is the number of frames an entity may be observed dead before
+	// it is reported.
+	Frames int
+
+	frame int
+	dead  map[E]*leakState
+}
+
+type leakState struct {
+	since    int // frame in which the entity was first observed dead
+	seen     int // frame in which the entity was last observed
+	reported bool
+}
+
+// Observe records the entities of a collection, including dead ones, for
+// the current frame. Call it for every collection being checked.
+func (d *LeakDetector[E]) Observe(xs ...E) {
+	for _, x := range xs {
+		if x.Alive() {
+			delete(d.dead, x)
+			continue
+		}
+		if d.dead == nil {
+			d.dead = map[E]*leakState{}
+		}
+		s, ok := d.dead[x]
+		if !ok {
+			s = &leakState{since: d.frame}
+			d.dead[x] = s
+		}
+		s.seen = d.frame
+	}
+}
+
+// Next ends the current frame and returns the entities that have been
+// observed dead for Frames frames. Each entity is reported once. Entities
+// not observed during the frame are assumed to have been swept and are
+// forgotten.
+func (d *LeakDetector[E]) Next() []E {
+	var leaks []E
+	for x, s := range d.dead {
+		switch {
+		case s.seen != d.frame:
+			delete(d.dead, x)
+		case !s.reported && d.frame-s.since+1 >= d.Frames:
+			s.reported = true
+			leaks = append(leaks, x)
+		}
+	}
+	d.frame++
+	return leaks
+}
@@ -0,0 +1,29 @@
+package ei
+
+import "testing"
+
+func TestLeakDetector(t *testing.T) {
+	d := LeakDetector[*Entity]{Frames: 3}
+	leaked, swept := &Entity{}, &Entity{}
+	xs := []*Entity{leaked, swept, {}}
+	leaked.Kill()
+	swept.Kill()
+	for frame := range 5 {
+		d.Observe(xs...)
+		got := d.Next()
+		if frame == 0 {
+			xs = xs[:1:1] // swept is no longer in a collection
+			xs = append(xs, &Entity{})
+		}
+		want := 0
+		if frame == 2 {
+			want = 1 // reported once, in the third frame
+		}
+		if len(got) != want || (want == 1 && got[0] != leaked) {
+			t.Errorf("frame %d: Next() = %v, want %d leaks", frame, got, want)
+		}
+	}
+	if _, ok := d.dead[swept]; ok {
+		t.Error("swept entity not forgotten")
+	}
+}
@@ -0,0 +1,80 @@
+package ei
+
+// List is a slice of entities with the sweeping built in.
+type List[E Interface] struct {
+	items   []E
+	pending []E // added by SpawnLater
+	dead    int // number of items known to be dead
+}
+
+// Add appends e.
+func (l *List[E]) Add(e E) {
+	l.items = append(l.items, e)
+	if !e.Alive() {
+		l.dead++
+	}
+}
+
+// SpawnLater queues e to be appended by the next Sweep. Unlike Add, it is
+// safe to call while ranging over the list, and the queued entity is not
+// visited until then.
+func (l *List[E]) SpawnLater(e E) { l.pending = append(l.pending, e) }
+
+// Len returns the number of entities, including dead ones not yet swept.
+func (l *List[E]) Len() int { return len(l.items) }
+
+// Cap returns the number of entities the list can hold without growing.
+func (l *List[E]) Cap() int { return cap(l.items) }
+
+// Reserve grows the list so that n more entities can be added without
+// another allocation.
+func (l *List[E]) Reserve(n int) { Reserve(&l.items, n) }
+
+// At returns the i-th entity.
+func (l *List[E]) At(i int) E { return l.items[i] }
+
+// Kill kills the i-th entity. It stays in the list until the next Sweep.
+func (l *List[E]) Kill(i int) {
+	e := l.items[i]
+	if e.Alive() {
+		e.Kill()
+		if !e.Alive() {
+			l.dead++
+		}
+	}
+}
+
+// CountAlive returns the number of live entities in O(1). The count follows
+// Add, Kill and Sweep of the list; entities killed directly rather than
+// through Kill are only accounted for by the next Sweep.
+func (l *List[E]) CountAlive() int { return len(l.items) - l.dead }
+
+// CountDead returns the number of dead entities not yet swept, with the same
+// caveat as CountAlive.
+func (l *List[E]) CountDead() int { return l.dead }
+
+// Range calls yield for each live entity and its index until yield returns
+// false. It can be used as a range-over-func iterator:
+//
+//	for i, e := range list.Range {
+//		...
+//	}
+func (l *List[E]) Range(yield func(int, E) bool) {
+	for i, x := range l.items {
+		if x.Alive() && !yield(i, x) {
+			return
+		}
+	}
+}
+
+// Sweep removes dead entities, keeping the rest in order, then appends the
+// entities queued by SpawnLater. It returns the number removed.
+func (l *List[E]) Sweep() int {
+	n := SweepN(&l.items)
+	l.dead = 0
+	for _, e := range l.pending {
+		l.Add(e)
+	}
+	Clear(&l.pending)
+	return n
+}
@@ -0,0 +1,63 @@
+package ei
+
+import "testing"
+
+func TestList(t *testing.T) {
+	var l List[*payload]
+	l.Reserve(4)
+	if l.Cap() < 4 {
+		t.Errorf("Cap() after Reserve(4) = %d", l.Cap())
+	}
+	for v := range 4 {
+		l.Add(&payload{v: v})
+	}
+	l.Kill(1)
+	var got []int
+	for i, e := range l.Range {
+		if l.At(i) != e {
+			t.Errorf("Range yielded index %d for the wrong entity", i)
+		}
+		got = append(got, e.v)
+		if i == 2 {
+			break
+		}
+	}
+	if len(got) != 2 || got[0] != 0 || got[1] != 2 {
+		t.Errorf("Range = %v, want [0 2]", got)
+	}
+	if n := l.Sweep(); n != 1 || l.Len() != 3 {
+		t.Errorf("Sweep() = %d, Len() = %d, want 1, 3", n, l.Len())
+	}
+}
+
+func TestListSpawnLater(t *testing.T) {
+	var l List[*payload]
+	l.Add(&payload{v: 1})
+	for _, e := range l.Range {
+		l.SpawnLater(&payload{v: e.v + 1})
+		e.Kill()
+	}
+	if l.Len() != 1 {
+		t.Fatalf("Len() before Sweep = %d, want 1", l.Len())
+	}
+	l.Sweep()
+	if l.Len() != 1 || l.At(0).v != 2 {
+		t.Errorf("after Sweep: Len() = %d, want one entity with v = 2", l.Len())
+	}
+}
+
+func TestListCount(t *testing.T) {
+	var l List[*Entity]
+	l.Add(&Entity{})
+	l.Add(&Entity{})
+	l.Add(&Entity{dead: true})
+	l.Kill(0)
+	l.Kill(0) // already dead: not counted twice
+	if l.CountAlive() != 1 || l.CountDead() != 2 {
+		t.Errorf("CountAlive(), CountDead() = %d, %d, want 1, 2", l.CountAlive(), l.CountDead())
+	}
+	l.Sweep()
+	if l.CountAlive() != 1 || l.CountDead() != 0 {
+		t.Errorf("after Sweep: CountAlive(), CountDead() = %d, %d, want 1, 0", l.CountAlive(), l.CountDead())
+	}
+}
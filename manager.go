@@ -0,0 +1,46 @@
+package ei
+
+// Updater is an entity updated once per frame.
+type Updater interface {
+	Interface
+	Update() error
+}
+
+// Manager is a List that runs the per-frame loop of a game such as one
+// built on Ebitengine:
+//
+//	func (g *Game) Update() error { return g.enemies.Update() }
+//
+//	func (g *Game) Draw(screen *ebiten.Image) {
+//		for e := range g.enemies.Draw {
+//			e.Draw(screen)
+//		}
+//	}
+type Manager[E Updater] struct {
+	List[E]
+}
+
+// Update calls Update on each live entity in order, then sweeps the list.
+// If an entity returns an error, the remaining entities are not updated and
+// the error is returned after the sweep.
+func (m *Manager[E]) Update() error {
+	var err error
+	for _, e := range m.Range {
+		if err = e.Update(); err != nil {
+			break
+		}
+	}
+	m.Sweep()
+	return err
+}
+
+// Draw calls yield for each live entity in order until yield returns false.
+// It is intended to be used as a range-over-func iterator in the Draw
+// method of a game.
+func (m *Manager[E]) Draw(yield func(E) bool) {
+	for _, e := range m.Range {
+		if !yield(e) {
+			return
+		}
+	}
+}
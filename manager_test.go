@@ -0,0 +1,60 @@
+package ei
+
+import (
+	"errors"
+	"testing"
+)
+
+var errQuit = errors.New("quit")
+
+// walker dies after walking a number of steps, and stops the game on a
+// negative one.
+type walker struct {
+	Entity
+	steps int
+}
+
+func (w *walker) Update() error {
+	if w.steps < 0 {
+		return errQuit
+	}
+	w.steps--
+	if w.steps == 0 {
+		w.Kill()
+	}
+	return nil
+}
+
+func TestManager(t *testing.T) {
+	var m Manager[*walker]
+	m.Add(&walker{steps: 1})
+	m.Add(&walker{steps: 2})
+	if err := m.Update(); err != nil {
+		t.Fatalf("Update() = %v", err)
+	}
+	if m.Len() != 1 {
+		t.Errorf("Len() after Update = %d, want 1", m.Len())
+	}
+
+	quitter := &walker{steps: -1}
+	m.Add(quitter)
+	m.Add(&walker{steps: 5})
+	if err := m.Update(); err != errQuit {
+		t.Errorf("Update() = %v, want %v", err, errQuit)
+	}
+	if last := m.At(m.Len() - 1); last.steps != 5 {
+		t.Errorf("entity after the failing one was updated: steps = %d", last.steps)
+	}
+	if m.Len() != 2 {
+		t.Errorf("Len() after a failed Update = %d, want 2: the list must be swept", m.Len())
+	}
+
+	n := 0
+	for range m.Draw {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Errorf("Draw yielded %d entities before the break, want 1", n)
+	}
+}
@@ -0,0 +1,19 @@
+package ei
+
+// MarshalAlive encodes the live elements in order using encode, stopping at
+// the first error. Dead elements are skipped. Decoding is left to the
+// caller, since only the caller knows how to rebuild its entities.
+func MarshalAlive[E Interface, S ~[]E](xs S, encode func(E) ([]byte, error)) ([][]byte, error) {
+	var out [][]byte
+	for _, x := range xs {
+		if !x.Alive() {
+			continue
+		}
+		b, err := encode(x)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, b)
+	}
+	return out, nil
+}
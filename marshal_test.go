@@ -0,0 +1,28 @@
+package ei
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestMarshalAlive(t *testing.T) {
+	xs := []*payload{{v: 1}, {v: 2}, {v: 3}}
+	xs[1].Kill()
+	encode := func(p *payload) ([]byte, error) { return strconv.AppendInt(nil, int64(p.v), 10), nil }
+	out, err := MarshalAlive(xs, encode)
+	if err != nil || len(out) != 2 || string(out[0]) != "1" || string(out[1]) != "3" {
+		t.Errorf("MarshalAlive() = %q, %v, want [1 3], nil", out, err)
+	}
+
+	errBad := errors.New("bad")
+	out, err = MarshalAlive(xs, func(p *payload) ([]byte, error) {
+		if p.v == 3 {
+			return nil, errBad
+		}
+		return encode(p)
+	})
+	if err != errBad || out != nil {
+		t.Errorf("MarshalAlive() = %q, %v, want nil, %v", out, err, errBad)
+	}
+}
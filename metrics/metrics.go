@@ -0,0 +1,79 @@
+// Package metrics publishes entity statistics with expvar, so that they can
+// be scraped and graphed by existing monitoring.
+package metrics
+
+import (
+	"expvar"
+	"time"
+
+	"github.com/eihigh/ei"
+)
+
+// Collection is a Sweeper that publishes statistics about the collection it
+// wraps under an expvar.Map:
+//
+//	alive        number of entities after the last sweep
+//	sweeps       number of sweeps
+//	removed      total number of removed entities
+//	sweep_ns     duration of the last sweep in nanoseconds
+//	sweep_ns_sum total duration of the sweeps in nanoseconds
+//
+// The kill rate of the collection is the rate of removed. The values are
+// only updated by Sweep, so publishing does not race with the game loop.
+//
+//	enemies := metrics.Slice("enemies", &g.enemies)
+//	...
+//	enemies.Sweep() // instead of ei.Sweep(&g.enemies)
+type Collection struct {
+	s     ei.Sweeper
+	count func() int
+
+	alive, sweeps, removed, sweepNS, sweepNSSum expvar.Int
+}
+
+// New publishes a Collection wrapping s under name. count returns the number
+// of entities in the collection and is called after each sweep, when they
+// are all alive. Like expvar.Publish, New panics if name is already in use.
+func New(name string, s ei.Sweeper, count func() int) *Collection {
+	c := &Collection{s: s, count: count}
+	m := expvar.NewMap(name)
+	m.Set("alive", &c.alive)
+	m.Set("sweeps", &c.sweeps)
+	m.Set("removed", &c.removed)
+	m.Set("sweep_ns", &c.sweepNS)
+	m.Set("sweep_ns_sum", &c.sweepNSSum)
+	return c
+}
+
+// Slice publishes a Collection sweeping *xs.
+func Slice[E ei.Interface, S ~[]E](name string, xs *S) *Collection {
+	return New(name, ei.Slice(xs), func() int { return len(*xs) })
+}
+
+// Map publishes a Collection sweeping m.
+func Map[K comparable, V ei.Interface, M ~map[K]V](name string, m M) *Collection {
+	return New(name, ei.Map(m), func() int { return len(m) })
+}
+
+// Sweep sweeps the collection, updates its statistics and returns the
+// number of removed entities.
+func (c *Collection) Sweep() int {
+	start := time.Now()
+	n := c.s.Sweep()
+	d := time.Since(start).Nanoseconds()
+	c.alive.Set(int64(c.count()))
+	c.sweeps.Add(1)
+	c.removed.Add(int64(n))
+	c.sweepNS.Set(d)
+	c.sweepNSSum.Add(d)
+	return n
+}
+
+// PublishStats publishes the counters of ei.Stats under name as a map with
+// the keys spawned, killed and alive.
+func PublishStats(name string) {
+	expvar.Publish(name, expvar.Func(func() any {
+		spawned, killed, alive := ei.Stats()
+		return map[string]uint64{"spawned": spawned, "killed": killed, "alive": alive}
+	}))
+}
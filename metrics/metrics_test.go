@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"testing"
+
+	"github.com/eihigh/ei"
+)
+
+var published int
+
+// unique returns a name not yet published, so that the tests can run more
+// than once in a process.
+func unique(name string) string {
+	published++
+	return fmt.Sprintf("%s_%d", name, published)
+}
+
+// get returns the value published under name and key.
+func get(t *testing.T, name, key string) int64 {
+	t.Helper()
+	m, ok := expvar.Get(name).(*expvar.Map)
+	if !ok {
+		t.Fatalf("%s not published as a map", name)
+	}
+	v, ok := m.Get(key).(*expvar.Int)
+	if !ok {
+		t.Fatalf("%s.%s not published", name, key)
+	}
+	return v.Value()
+}
+
+func TestSlice(t *testing.T) {
+	xs := []*ei.Entity{{}, {}, {}}
+	name := unique("test_slice")
+	c := Slice(name, &xs)
+	xs[0].Kill()
+	if n := c.Sweep(); n != 1 {
+		t.Errorf("Sweep() = %d, want 1", n)
+	}
+	xs[0].Kill()
+	c.Sweep()
+	for key, want := range map[string]int64{"alive": 1, "sweeps": 2, "removed": 2} {
+		if got := get(t, name, key); got != want {
+			t.Errorf("%s = %d, want %d", key, got, want)
+		}
+	}
+	if get(t, name, "sweep_ns_sum") < get(t, name, "sweep_ns") {
+		t.Error("sweep_ns_sum < sweep_ns")
+	}
+}
+
+func TestMap(t *testing.T) {
+	m := map[int]*ei.Entity{1: {}, 2: {}}
+	name := unique("test_map")
+	c := Map(name, m)
+	m[1].Kill()
+	c.Sweep()
+	if got := get(t, name, "alive"); got != 1 {
+		t.Errorf("alive = %d, want 1", got)
+	}
+}
+
+func TestPublishStats(t *testing.T) {
+	name := unique("test_stats")
+	PublishStats(name)
+	var stats map[string]uint64
+	if err := json.Unmarshal([]byte(expvar.Get(name).String()), &stats); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"spawned", "killed", "alive"} {
+		if _, ok := stats[key]; !ok {
+			t.Errorf("missing %s in %v", key, stats)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package ei
+
+import "slices"
+
+// Node is an Entity that owns child entities. Killing a node kills its
+// children as well, so the next sweep removes the whole subtree from
+// whichever collections hold its members.
+type Node struct {
+	Entity
+	children []Interface
+}
+
+// AddChild makes c a child of the node.
+func (n *Node) AddChild(c Interface) { n.children = append(n.children, c) }
+
+// Children returns the children of the node, including dead ones not yet
+// removed by SweepChildren.
+func (n *Node) Children() []Interface { return n.children }
+
+// SweepChildren forgets dead children. Unlike Sweep, it does not dispose
+// them, since the collections holding the children dispose them when they
+// are swept.
+func (n *Node) SweepChildren() {
+	n.children = slices.DeleteFunc(n.children, IsDead[Interface])
+}
+
+func (n *Node) Kill() { n.TryKill() }
+
+// TryKill kills the node and its children and reports whether the node was
+// alive before. Children are only killed when the node dies, so cycles of
+// nodes terminate.
+func (n *Node) TryKill() bool { return n.tryKill(nil) }
+
+// KillWith is like Kill, but records reason on the node as Entity.KillWith
+// does. The children are killed without a reason.
+func (n *Node) KillWith(reason any) { n.tryKill(reason) }
+
+func (n *Node) tryKill(reason any) bool {
+	if !n.Entity.tryKill(reason) {
+		return false
+	}
+	for _, c := range n.children {
+		c.Kill()
+	}
+	return true
+}
@@ -0,0 +1,48 @@
+package ei
+
+import "testing"
+
+func TestNode(t *testing.T) {
+	var root, child Node
+	var leaf Entity
+	root.AddChild(&child)
+	child.AddChild(&leaf)
+	child.AddChild(&root) // a cycle terminates
+
+	root.KillWith("boss defeated")
+	if root.Alive() || child.Alive() || leaf.Alive() {
+		t.Errorf("alive after killing the root: root %v, child %v, leaf %v", root.Alive(), child.Alive(), leaf.Alive())
+	}
+	if root.Reason() != "boss defeated" || child.Reason() != nil {
+		t.Errorf("reasons = %v, %v, want the reason on the root only", root.Reason(), child.Reason())
+	}
+	if root.TryKill() {
+		t.Error("TryKill() of a dead node = true")
+	}
+
+	xs := []Interface{&root, &child, &leaf}
+	Sweep(&xs)
+	if len(xs) != 0 {
+		t.Errorf("len(xs) = %d, want 0", len(xs))
+	}
+	child.SweepChildren()
+	if len(child.Children()) != 0 {
+		t.Errorf("Children() after SweepChildren = %v", child.Children())
+	}
+}
+
+func TestNodeSweepChildrenDispose(t *testing.T) {
+	var parent Node
+	child := &disposed{}
+	parent.AddChild(child)
+	xs := []*disposed{child}
+	parent.Kill()
+	parent.SweepChildren()
+	Sweep(&xs)
+	if child.n != 1 {
+		t.Errorf("child disposed %d times, want 1", child.n)
+	}
+	if len(parent.Children()) != 0 || len(xs) != 0 {
+		t.Errorf("child not removed: %d children, len(xs) = %d", len(parent.Children()), len(xs))
+	}
+}
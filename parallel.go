@@ -0,0 +1,56 @@
+package ei
+
+import (
+	"runtime"
+	"sync"
+)
+
+// parallelMin is the slice length below which SweepParallel does not start
+// goroutines.
+const parallelMin = 1 << 14
+
+// SweepParallel is like Sweep, but evaluates Alive on GOMAXPROCS goroutines
+// before compacting the slice on the calling goroutine. It only pays off for
+// very large slices; short ones are swept directly. Alive must be safe to
+// call concurrently, which always holds for EntityAtomic and holds for
+// Entity as long as nothing kills it during the sweep.
+func SweepParallel[E Interface, S ~[]E](xs *S) {
+	if xs == nil {
+		return
+	}
+	s := *xs
+	procs := runtime.GOMAXPROCS(0)
+	if len(s) < parallelMin || procs == 1 {
+		Sweep(xs)
+		return
+	}
+
+	// Mark
+	keep := make([]bool, len(s))
+	chunk := (len(s) + procs - 1) / procs
+	var wg sync.WaitGroup
+	for start := 0; start < len(s); start += chunk {
+		end := min(start+chunk, len(s))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				keep[i] = s[i].Alive()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Compact
+	j := 0
+	for i, x := range s {
+		if keep[i] {
+			s[j] = x
+			j++
+		} else {
+			dispose(x)
+		}
+	}
+	clear(s[j:])
+	*xs = s[:j]
+}
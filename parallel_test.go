@@ -0,0 +1,43 @@
+package ei
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSweepParallel(t *testing.T) {
+	for _, n := range []int{10, parallelMin + 123} {
+		xs := newEntities(n)
+		churn(xs, 0)
+		xs[n-1].Kill()
+		want := slices.Clone(xs)
+		Sweep(&want)
+		SweepParallel(&xs)
+		if !slices.Equal(xs, want) {
+			t.Errorf("n = %d: SweepParallel kept %d elements, Sweep %d", n, len(xs), len(want))
+		}
+	}
+}
+
+func BenchmarkSweepParallel(b *testing.B) {
+	for _, bb := range []struct {
+		name  string
+		sweep func(*[]*Entity)
+	}{
+		{"Sweep", Sweep[*Entity, []*Entity]},
+		{"SweepParallel", SweepParallel[*Entity, []*Entity]},
+	} {
+		b.Run(bb.name, func(b *testing.B) {
+			all := newEntities(1 << 20)
+			xs := make([]*Entity, 0, len(all))
+			for i := range b.N {
+				xs = append(xs[:0], all...)
+				churn(xs, i)
+				bb.sweep(&xs)
+				for _, x := range all {
+					x.dead = false
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package ei
+
+// Pool keeps dead entities for reuse, so that games spawning many
+// short-lived entities do not allocate for each one.
+//
+//	var bullets []*Bullet
+//	pool := ei.Pool[*Bullet]{New: func() *Bullet { return &Bullet{} }}
+//	b := pool.Get()
+//	bullets = append(bullets, b)
+//	...
+//	ei.SweepPool(&bullets, &pool) // dead bullets go back to the pool
+type Pool[E Interface] struct {
+	// New, if non-nil, creates an entity when the pool is empty. Otherwise
+	// Get returns the zero value.
+	New func() E
+
+	free []E
+}
+
+// Get removes an entity from the pool and returns it, or creates a new one
+// if the pool is empty. A pooled entity is reset if it implements
+// Resettable and revived if it implements Reviver before it is returned.
+func (p *Pool[E]) Get() E {
+	n := len(p.free)
+	if n == 0 {
+		if p.New != nil {
+			return p.New()
+		}
+		var zero E
+		return zero
+	}
+	e := p.free[n-1]
+	var zero E
+	p.free[n-1] = zero
+	p.free = p.free[:n-1]
+	recycle(e)
+	return e
+}
+
+// Put adds e to the pool. The caller must not use e afterwards.
+func (p *Pool[E]) Put(e E) { p.free = append(p.free, e) }
+
+// Len returns the number of pooled entities.
+func (p *Pool[E]) Len() int { return len(p.free) }
+
+// SweepPool removes dead elements from *xs like Sweep and puts them into p
+// instead of disposing them.
+func SweepPool[E Interface, S ~[]E](xs *S, p *Pool[E]) {
+	if xs == nil {
+		return
+	}
+	j := 0
+	for _, x := range *xs {
+		if x.Alive() {
+			(*xs)[j] = x
+			j++
+		} else {
+			p.Put(x)
+		}
+	}
+	clear((*xs)[j:])
+	*xs = (*xs)[:j]
+}
@@ -0,0 +1,42 @@
+package ei
+
+import "testing"
+
+type pooled struct {
+	Entity
+	resets int
+}
+
+func (p *pooled) Reset() {
+	p.Entity.Reset()
+	p.resets++
+}
+
+type pooledList []*pooled
+
+func TestPool(t *testing.T) {
+	var pool Pool[*pooled]
+	if e := pool.Get(); e != nil {
+		t.Fatalf("Get() on an empty pool without New = %v, want nil", e)
+	}
+	pool.New = func() *pooled { return &pooled{} }
+	a, b := pool.Get(), pool.Get()
+	if a == nil || a == b {
+		t.Fatal("Get() did not create distinct entities")
+	}
+
+	xs := pooledList{a, b}
+	a.Kill()
+	SweepPool(&xs, &pool)
+	if len(xs) != 1 || xs[0] != b || pool.Len() != 1 {
+		t.Fatalf("after SweepPool: len(xs) = %d, pool.Len() = %d", len(xs), pool.Len())
+	}
+
+	got := pool.Get()
+	if got != a || !got.Alive() || got.resets != 1 {
+		t.Errorf("Get() = %p alive=%v resets=%d, want the recycled entity", got, got.Alive(), got.resets)
+	}
+	if pool.Len() != 0 {
+		t.Errorf("pool.Len() = %d, want 0", pool.Len())
+	}
+}
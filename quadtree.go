@@ -0,0 +1,201 @@
+package ei
+
+import "iter"
+
+const (
+	quadMaxItems = 8 // items per leaf before it splits
+	quadMaxDepth = 8 // depth below which leaves no longer split
+)
+
+// Quadtree holds positioned entities in a tree of nested quadrants, which
+// suits large worlds where entities cluster unevenly. Entities positioned
+// outside the bounds are kept in the root.
+type Quadtree[E interface {
+	Interface
+	comparable
+}] struct {
+	root *quadNode[E]
+	pos  map[E]quadPos[E]
+}
+
+type quadNode[E any] struct {
+	bounds   Rect
+	depth    int
+	items    []E
+	children *[4]*quadNode[E] // nil for a leaf
+}
+
+type quadPos[E any] struct {
+	x, y float64
+	node *quadNode[E]
+}
+
+// NewQuadtree returns an empty tree covering bounds.
+func NewQuadtree[E interface {
+	Interface
+	comparable
+}](bounds Rect) *Quadtree[E] {
+	return &Quadtree[E]{
+		root: &quadNode[E]{bounds: bounds},
+		pos:  map[E]quadPos[E]{},
+	}
+}
+
+// quadrant returns the index of the child of n containing (x, y), or -1 if
+// the point is outside n.
+func (n *quadNode[E]) quadrant(x, y float64) int {
+	if !n.bounds.Contains(x, y) {
+		return -1
+	}
+	i := 0
+	if x >= (n.bounds.MinX+n.bounds.MaxX)/2 {
+		i |= 1
+	}
+	if y >= (n.bounds.MinY+n.bounds.MaxY)/2 {
+		i |= 2
+	}
+	return i
+}
+
+// Insert adds e at (x, y). Inserting an entity that is already present
+// moves it like Move.
+func (t *Quadtree[E]) Insert(e E, x, y float64) {
+	if _, ok := t.pos[e]; ok {
+		t.Move(e, x, y)
+		return
+	}
+	t.insert(t.root, e, x, y)
+}
+
+func (t *Quadtree[E]) insert(n *quadNode[E], e E, x, y float64) {
+	for n.children != nil {
+		i := n.quadrant(x, y)
+		if i < 0 {
+			break
+		}
+		n = n.children[i]
+	}
+	n.items = append(n.items, e)
+	t.pos[e] = quadPos[E]{x, y, n}
+	if n.children == nil && len(n.items) > quadMaxItems && n.depth < quadMaxDepth {
+		t.split(n)
+	}
+}
+
+func (t *Quadtree[E]) split(n *quadNode[E]) {
+	b := n.bounds
+	midX, midY := (b.MinX+b.MaxX)/2, (b.MinY+b.MaxY)/2
+	n.children = &[4]*quadNode[E]{
+		{bounds: Rect{b.MinX, b.MinY, midX, midY}, depth: n.depth + 1},
+		{bounds: Rect{midX, b.MinY, b.MaxX, midY}, depth: n.depth + 1},
+		{bounds: Rect{b.MinX, midY, midX, b.MaxY}, depth: n.depth + 1},
+		{bounds: Rect{midX, midY, b.MaxX, b.MaxY}, depth: n.depth + 1},
+	}
+	items := n.items
+	n.items = nil
+	for _, e := range items {
+		p := t.pos[e]
+		if i := n.quadrant(p.x, p.y); i >= 0 {
+			t.insert(n.children[i], e, p.x, p.y)
+		} else {
+			n.items = append(n.items, e)
+		}
+	}
+}
+
+// Move updates the position of e. It does nothing if e is not present.
+func (t *Quadtree[E]) Move(e E, x, y float64) {
+	p, ok := t.pos[e]
+	if !ok {
+		return
+	}
+	if p.node.children == nil && p.node.quadrant(x, y) >= 0 {
+		t.pos[e] = quadPos[E]{x, y, p.node} // still in the same leaf
+		return
+	}
+	items := p.node.items
+	for i, x := range items {
+		if x == e {
+			p.node.items = append(items[:i], items[i+1:]...)
+			var zero E
+			items[len(items)-1] = zero
+			break
+		}
+	}
+	t.insert(t.root, e, x, y)
+}
+
+// Query returns an iterator over the live entities positioned in r, in no
+// particular order.
+func (t *Quadtree[E]) Query(r Rect) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		t.query(t.root, r, yield)
+	}
+}
+
+func (t *Quadtree[E]) query(n *quadNode[E], r Rect, yield func(E) bool) bool {
+	for _, e := range n.items {
+		p := t.pos[e]
+		if r.Contains(p.x, p.y) && e.Alive() && !yield(e) {
+			return false
+		}
+	}
+	if n.children != nil {
+		for _, c := range n.children {
+			if c.bounds.Intersects(r) && !t.query(c, r, yield) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Len returns the number of entities, including dead ones not yet swept.
+func (t *Quadtree[E]) Len() int { return len(t.pos) }
+
+// Sweep removes dead entities and merges quadrants that no longer hold
+// enough entities to be worth splitting. It returns the number removed.
+func (t *Quadtree[E]) Sweep() int {
+	removed, _ := t.sweep(t.root)
+	return removed
+}
+
+// sweep prunes the subtree of n and returns the number of removed entities
+// and the number of entities left in it.
+func (t *Quadtree[E]) sweep(n *quadNode[E]) (removed, count int) {
+	j := 0
+	for _, e := range n.items {
+		if e.Alive() {
+			n.items[j] = e
+			j++
+		} else {
+			delete(t.pos, e)
+			dispose(e)
+			removed++
+		}
+	}
+	clear(n.items[j:])
+	n.items = n.items[:j]
+	count = j
+	if n.children == nil {
+		return removed, count
+	}
+	for _, c := range n.children {
+		r, k := t.sweep(c)
+		removed += r
+		count += k
+	}
+	if count <= quadMaxItems {
+		// Collapse the children, which are leaves by now, into n.
+		for _, c := range n.children {
+			for _, e := range c.items {
+				p := t.pos[e]
+				p.node = n
+				t.pos[e] = p
+				n.items = append(n.items, e)
+			}
+		}
+		n.children = nil
+	}
+	return removed, count
+}
@@ -0,0 +1,48 @@
+package ei
+
+import "testing"
+
+func TestQuadtree(t *testing.T) {
+	q := NewQuadtree[*payload](Rect{0, 0, 100, 100})
+	var xs []*payload
+	for i := range 40 {
+		e := &payload{v: i}
+		xs = append(xs, e)
+		q.Insert(e, float64(i*2), float64(i*2))
+	}
+	q.Insert(&payload{v: -1}, 500, 500) // outside the bounds: kept in the root
+	if q.root.children == nil {
+		t.Fatal("root did not split")
+	}
+	count := func(r Rect) int {
+		n := 0
+		for range q.Query(r) {
+			n++
+		}
+		return n
+	}
+	if n := count(Rect{0, 0, 9, 9}); n != 5 {
+		t.Errorf("Query() found %d entities, want 5", n)
+	}
+	if n := count(Rect{400, 400, 600, 600}); n != 1 {
+		t.Errorf("Query() outside the bounds found %d entities, want 1", n)
+	}
+
+	q.Move(xs[0], 99, 1)
+	if n := count(Rect{90, 0, 100, 10}); n != 1 {
+		t.Errorf("Query() after Move found %d entities, want 1", n)
+	}
+
+	for _, e := range xs[4:] {
+		e.Kill()
+	}
+	if n := q.Sweep(); n != 36 || q.Len() != 5 {
+		t.Errorf("Sweep() = %d, Len() = %d, want 36, 5", n, q.Len())
+	}
+	if q.root.children != nil {
+		t.Error("root not collapsed after most entities died")
+	}
+	if n := count(Rect{0, 0, 100, 100}); n != 4 {
+		t.Errorf("Query() after Sweep found %d entities, want 4", n)
+	}
+}
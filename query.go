@@ -0,0 +1,144 @@
+package ei
+
+import "iter"
+
+// CountAlive returns the number of live elements.
+func CountAlive[E Interface, S ~[]E](xs S) int {
+	n := 0
+	for _, x := range xs {
+		if x.Alive() {
+			n++
+		}
+	}
+	return n
+}
+
+// CountDead returns the number of dead elements, that is, how many the next
+// Sweep would remove.
+func CountDead[E Interface, S ~[]E](xs S) int {
+	return len(xs) - CountAlive(xs)
+}
+
+// AnyAlive reports whether at least one element is alive.
+func AnyAlive[E Interface, S ~[]E](xs S) bool {
+	for _, x := range xs {
+		if x.Alive() {
+			return true
+		}
+	}
+	return false
+}
+
+// AllDead reports whether every element is dead. It is true for an empty
+// slice.
+func AllDead[E Interface, S ~[]E](xs S) bool { return !AnyAlive(xs) }
+
+// AnyAliveMap reports whether at least one value is alive.
+func AnyAliveMap[K comparable, V Interface, M ~map[K]V](m M) bool {
+	for _, v := range m {
+		if v.Alive() {
+			return true
+		}
+	}
+	return false
+}
+
+// AllDeadMap reports whether every value is dead. It is true for an empty
+// map.
+func AllDeadMap[K comparable, V Interface, M ~map[K]V](m M) bool { return !AnyAliveMap(m) }
+
+// FindAlive returns the first live element for which pred returns true,
+// along with its index. Dead elements are skipped without calling pred. If
+// nothing matches, it returns the zero value, -1 and false.
+func FindAlive[E Interface, S ~[]E](xs S, pred func(E) bool) (E, int, bool) {
+	for i, x := range xs {
+		if x.Alive() && pred(x) {
+			return x, i, true
+		}
+	}
+	var zero E
+	return zero, -1, false
+}
+
+// AliveSeq returns an iterator over the live elements and their indices.
+// The slice is not modified.
+func AliveSeq[E Interface, S ~[]E](xs S) iter.Seq2[int, E] {
+	return func(yield func(int, E) bool) {
+		for i, x := range xs {
+			if x.Alive() && !yield(i, x) {
+				return
+			}
+		}
+	}
+}
+
+// AliveSeqMap returns an iterator over the live entries of m, for composing
+// with functions such as maps.Collect. The map is not modified.
+func AliveSeqMap[K comparable, V Interface, M ~map[K]V](m M) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range m {
+			if v.Alive() && !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// DeadSeq returns an iterator over the dead elements and their indices.
+// The slice is not modified.
+func DeadSeq[E Interface, S ~[]E](xs S) iter.Seq2[int, E] {
+	return func(yield func(int, E) bool) {
+		for i, x := range xs {
+			if !x.Alive() && !yield(i, x) {
+				return
+			}
+		}
+	}
+}
+
+// Partition returns the live and dead elements in two new slices, each in
+// the original order. The input is not modified. Use PartitionAppend to
+// reuse existing slices instead of allocating.
+func Partition[E Interface, S ~[]E](xs S) (alive S, dead S) {
+	return PartitionAppend(xs, nil, nil)
+}
+
+// PartitionAppend is like Partition, but appends to aliveDst and deadDst and
+// returns the extended slices.
+func PartitionAppend[E Interface, S ~[]E](xs, aliveDst, deadDst S) (alive S, dead S) {
+	for _, x := range xs {
+		if x.Alive() {
+			aliveDst = append(aliveDst, x)
+		} else {
+			deadDst = append(deadDst, x)
+		}
+	}
+	return aliveDst, deadDst
+}
+
+// Filter returns an iterator over the live elements, without their indices,
+// for composing with functions such as slices.Collect. The slice is not
+// modified.
+func Filter[E Interface, S ~[]E](xs S) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for _, x := range xs {
+			if x.Alive() && !yield(x) {
+				return
+			}
+		}
+	}
+}
+
+// PartitionMap returns the live and dead entries of m in two new maps. The
+// input is not modified.
+func PartitionMap[K comparable, V Interface, M ~map[K]V](m M) (alive M, dead M) {
+	alive, dead = M{}, M{}
+	for k, v := range m {
+		if v.Alive() {
+			alive[k] = v
+		} else {
+			dead[k] = v
+		}
+	}
+	return alive, dead
+}
@@ -0,0 +1,143 @@
+package ei
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+// mixed returns five entities of which the ones at indices 1 and 3 are dead.
+func mixed() []*Entity {
+	xs := newEntities(5)
+	xs[1].Kill()
+	xs[3].Kill()
+	return xs
+}
+
+func TestCount(t *testing.T) {
+	xs := mixed()
+	if n := CountAlive(xs); n != 3 {
+		t.Errorf("CountAlive() = %d, want 3", n)
+	}
+	if n := CountDead(xs); n != 2 {
+		t.Errorf("CountDead() = %d, want 2", n)
+	}
+	if n := CountDead([]*Entity(nil)); n != 0 {
+		t.Errorf("CountDead(nil) = %d, want 0", n)
+	}
+}
+
+func TestAliveSeq(t *testing.T) {
+	xs := mixed()
+	var alive, dead []int
+	for i, x := range AliveSeq(xs) {
+		if x != xs[i] {
+			t.Errorf("AliveSeq yielded %p at %d, want %p", x, i, xs[i])
+		}
+		alive = append(alive, i)
+	}
+	for i := range DeadSeq(xs) {
+		dead = append(dead, i)
+	}
+	if len(alive) != 3 || alive[0] != 0 || alive[1] != 2 || alive[2] != 4 {
+		t.Errorf("AliveSeq indices = %v, want [0 2 4]", alive)
+	}
+	if len(dead) != 2 || dead[0] != 1 || dead[1] != 3 {
+		t.Errorf("DeadSeq indices = %v, want [1 3]", dead)
+	}
+	for range DeadSeq(xs) {
+		break // stopping early must not panic
+	}
+}
+
+func TestPartition(t *testing.T) {
+	xs := mixed()
+	orig := slices.Clone(xs)
+	alive, dead := Partition(xs)
+	if !slices.Equal(alive, []*Entity{xs[0], xs[2], xs[4]}) {
+		t.Errorf("alive = %v, want [0 2 4]", alive)
+	}
+	if !slices.Equal(dead, []*Entity{xs[1], xs[3]}) {
+		t.Errorf("dead = %v, want [1 3]", dead)
+	}
+	if !slices.Equal(xs, orig) {
+		t.Error("Partition modified its input")
+	}
+
+	a := make([]*Entity, 0, 8)
+	alive, _ = PartitionAppend(xs, a, nil)
+	if len(alive) != 3 || &alive[0] != &a[:1][0] {
+		t.Error("PartitionAppend did not append to aliveDst")
+	}
+}
+
+func TestAnyAlive(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		xs   []*Entity
+		any  bool
+	}{
+		{"empty", nil, false},
+		{"mixed", mixed(), true},
+		{"dead", []*Entity{{dead: true}, {dead: true}}, false},
+	} {
+		if got := AnyAlive(tt.xs); got != tt.any {
+			t.Errorf("%s: AnyAlive() = %v, want %v", tt.name, got, tt.any)
+		}
+		if got := AllDead(tt.xs); got != !tt.any {
+			t.Errorf("%s: AllDead() = %v, want %v", tt.name, got, !tt.any)
+		}
+	}
+	if !AllDeadMap(map[int]*Entity{}) || !AnyAliveMap(map[int]*Entity{1: {}}) {
+		t.Error("map variants disagree with the slice ones")
+	}
+}
+
+func TestFindAlive(t *testing.T) {
+	xs := []*payload{{v: 1}, {v: 2}, {v: 2}}
+	xs[1].Kill()
+	is2 := func(p *payload) bool { return p.v == 2 }
+	if x, i, ok := FindAlive(xs, is2); !ok || i != 2 || x != xs[2] {
+		t.Errorf("FindAlive() = %v, %d, %v, want xs[2], 2, true", x, i, ok)
+	}
+	xs[2].Kill()
+	if x, i, ok := FindAlive(xs, is2); ok || i != -1 || x != nil {
+		t.Errorf("FindAlive() with only dead matches = %v, %d, %v, want nil, -1, false", x, i, ok)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	xs := mixed()
+	got := slices.Collect(Filter(xs))
+	if !slices.Equal(got, []*Entity{xs[0], xs[2], xs[4]}) {
+		t.Errorf("Filter() = %v, want [0 2 4]", got)
+	}
+	if len(xs) != 5 {
+		t.Error("Filter modified its input")
+	}
+}
+
+func TestAliveSeqMap(t *testing.T) {
+	m := map[string]*Entity{"a": {}, "b": {dead: true}, "c": {}}
+	got := maps.Collect(AliveSeqMap(m))
+	if len(got) != 2 || got["a"] != m["a"] || got["c"] != m["c"] {
+		t.Errorf("AliveSeqMap() = %v, want a and c", got)
+	}
+	if len(m) != 3 {
+		t.Error("AliveSeqMap modified the map")
+	}
+}
+
+func TestPartitionMap(t *testing.T) {
+	m := map[string]*Entity{"a": {}, "b": {dead: true}, "c": {}}
+	alive, dead := PartitionMap(m)
+	if len(alive) != 2 || alive["a"] == nil || alive["c"] == nil {
+		t.Errorf("alive = %v, want a and c", alive)
+	}
+	if len(dead) != 1 || dead["b"] == nil {
+		t.Errorf("dead = %v, want b", dead)
+	}
+	if len(m) != 3 {
+		t.Error("PartitionMap modified its input")
+	}
+}
@@ -0,0 +1,38 @@
+package ei
+
+// Ref is a reference to an entity that reports when the entity is gone,
+// such as the target of a homing missile:
+//
+//	m.target = ei.NewRef(enemy)
+//	...
+//	if t, ok := m.target.Get(); ok {
+//		m.steerTo(t.Pos)
+//	}
+//
+// Once Get finds the entity dead, the Ref drops it and reports false from
+// then on, even if the entity is revived. A Ref cannot tell whether the
+// entity was killed and revived between two calls to Get, as happens with a
+// Pool; use an Arena Handle in that case. The zero Ref refers to nothing.
+type Ref[E Interface] struct {
+	e  E
+	ok bool
+}
+
+// NewRef returns a reference to e, or the zero Ref if e is dead.
+func NewRef[E Interface](e E) Ref[E] {
+	if !e.Alive() {
+		return Ref[E]{}
+	}
+	return Ref[E]{e, true}
+}
+
+// Get returns the entity and reports whether it is still alive.
+func (r *Ref[E]) Get() (E, bool) {
+	if r.ok && !r.e.Alive() {
+		r.Clear()
+	}
+	return r.e, r.ok
+}
+
+// Clear makes r refer to nothing.
+func (r *Ref[E]) Clear() { *r = Ref[E]{} }
@@ -0,0 +1,40 @@
+package ei
+
+import "testing"
+
+func TestRef(t *testing.T) {
+	e := &Entity{}
+	r := NewRef(e)
+	if got, ok := r.Get(); !ok || got != e {
+		t.Fatalf("Get() = %v, %v, want e, true", got, ok)
+	}
+	e.Kill()
+	if got, ok := r.Get(); ok || got != nil {
+		t.Errorf("Get() after Kill = %v, %v, want nil, false", got, ok)
+	}
+	e.Revive()
+	if got, ok := r.Get(); ok || got != nil {
+		t.Errorf("Get() after Revive = %v, %v, want nil, false", got, ok)
+	}
+}
+
+func TestRefDead(t *testing.T) {
+	e := &Entity{}
+	e.Kill()
+	r := NewRef(e)
+	if got, ok := r.Get(); ok || got != nil {
+		t.Errorf("Get() = %v, %v, want nil, false", got, ok)
+	}
+	var zero Ref[*Entity]
+	if got, ok := zero.Get(); ok || got != nil {
+		t.Errorf("zero Ref: Get() = %v, %v, want nil, false", got, ok)
+	}
+}
+
+func TestRefClear(t *testing.T) {
+	r := NewRef(&Entity{})
+	r.Clear()
+	if _, ok := r.Get(); ok {
+		t.Error("Get() after Clear reported ok")
+	}
+}
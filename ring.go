@@ -0,0 +1,81 @@
+package ei
+
+import "iter"
+
+// Ring is a fixed-capacity collection where pushing past the capacity
+// overwrites the oldest entity.
+type Ring[E Interface] struct {
+	// KillOverwritten makes Push kill an overwritten entity that is still
+	// alive.
+	KillOverwritten bool
+
+	buf  []E
+	head int // index of the oldest entity
+	size int
+}
+
+// NewRing returns an empty ring holding at most n entities.
+func NewRing[E Interface](n int) *Ring[E] {
+	if n <= 0 {
+		panic("ei: NewRing: non-positive capacity")
+	}
+	return &Ring[E]{buf: make([]E, n)}
+}
+
+// Push adds e as the newest entity, overwriting the oldest one if the ring
+// is full. The overwritten entity is disposed, as if it had been swept.
+func (r *Ring[E]) Push(e E) {
+	if r.size < len(r.buf) {
+		r.buf[(r.head+r.size)%len(r.buf)] = e
+		r.size++
+		return
+	}
+	old := r.buf[r.head]
+	if r.KillOverwritten && old.Alive() {
+		old.Kill()
+	}
+	dispose(old)
+	r.buf[r.head] = e
+	r.head = (r.head + 1) % len(r.buf)
+}
+
+// Sweep removes dead entities, keeping the rest in insertion order, and
+// returns the number removed.
+func (r *Ring[E]) Sweep() int {
+	j := 0
+	for i := 0; i < r.size; i++ {
+		x := r.buf[(r.head+i)%len(r.buf)]
+		if x.Alive() {
+			r.buf[(r.head+j)%len(r.buf)] = x
+			j++
+		} else {
+			dispose(x)
+		}
+	}
+	var zero E
+	for i := j; i < r.size; i++ {
+		r.buf[(r.head+i)%len(r.buf)] = zero
+	}
+	n := r.size - j
+	r.size = j
+	return n
+}
+
+// All returns an iterator over the live entities from oldest to newest.
+func (r *Ring[E]) All() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for i := 0; i < r.size; i++ {
+			x := r.buf[(r.head+i)%len(r.buf)]
+			if x.Alive() && !yield(x) {
+				return
+			}
+		}
+	}
+}
+
+// Len returns the number of stored entities, including dead ones not yet
+// swept.
+func (r *Ring[E]) Len() int { return r.size }
+
+// Cap returns the maximum number of entities.
+func (r *Ring[E]) Cap() int { return len(r.buf) }
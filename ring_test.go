@@ -0,0 +1,92 @@
+package ei
+
+import (
+	"slices"
+	"testing"
+)
+
+func ringValues(r *Ring[*disposed]) []int {
+	var got []int
+	for e := range r.All() {
+		got = append(got, e.n)
+	}
+	return got
+}
+
+func TestRingPush(t *testing.T) {
+	r := NewRing[*disposed](2)
+	a, b, c := &disposed{}, &disposed{}, &disposed{}
+	r.Push(a)
+	r.Push(b)
+	r.Push(c)
+	if r.Len() != 2 || r.Cap() != 2 {
+		t.Fatalf("Len() = %d, Cap() = %d, want 2, 2", r.Len(), r.Cap())
+	}
+	if a.n != 1 {
+		t.Errorf("overwritten entity disposed %d times, want 1", a.n)
+	}
+	if !a.Alive() {
+		t.Error("overwritten entity killed without KillOverwritten")
+	}
+	var got []*disposed
+	for e := range r.All() {
+		got = append(got, e)
+	}
+	if !slices.Equal(got, []*disposed{b, c}) {
+		t.Errorf("All() = %v, want [b c]", got)
+	}
+}
+
+func TestRingKillOverwritten(t *testing.T) {
+	r := NewRing[*disposed](1)
+	r.KillOverwritten = true
+	a := &disposed{}
+	r.Push(a)
+	r.Push(&disposed{})
+	if a.Alive() || a.n != 1 {
+		t.Errorf("overwritten entity: alive = %v, disposed %d times", a.Alive(), a.n)
+	}
+}
+
+func TestRingSweep(t *testing.T) {
+	r := NewRing[*disposed](3)
+	xs := []*disposed{{}, {}, {}, {}}
+	for _, x := range xs {
+		r.Push(x)
+	}
+	xs[2].Kill()
+	if n := r.Sweep(); n != 1 || r.Len() != 2 || xs[2].n != 1 {
+		t.Fatalf("Sweep() = %d, Len() = %d, disposed %d times", n, r.Len(), xs[2].n)
+	}
+	r.Push(&disposed{n: 5})
+	if got := ringValues(r); !slices.Equal(got, []int{0, 0, 5}) {
+		t.Errorf("values = %v, want [0 0 5]", got)
+	}
+}
+
+func TestRingWrap(t *testing.T) {
+	r := NewRing[*payload](3)
+	for v := range 7 {
+		r.Push(&payload{v: v})
+	}
+	var got []int
+	for e := range r.All() {
+		got = append(got, e.v)
+		if e.v == 5 {
+			e.Kill()
+		}
+	}
+	if !slices.Equal(got, []int{4, 5, 6}) {
+		t.Errorf("All() = %v, want [4 5 6]", got)
+	}
+	r.Sweep()
+	r.Push(&payload{v: 7})
+	r.Push(&payload{v: 8})
+	got = got[:0]
+	for e := range r.All() {
+		got = append(got, e.v)
+	}
+	if !slices.Equal(got, []int{6, 7, 8}) {
+		t.Errorf("All() after a sweep across the wrap = %v, want [6 7 8]", got)
+	}
+}
@@ -0,0 +1,42 @@
+package ei
+
+// Snapshot returns a new slice holding the live elements of xs, for rolling
+// back to later with Restore.
+//
+// The copy is shallow: for pointer elements, the snapshot shares the
+// entities themselves, so later changes to them, including kills, show
+// through. Use SnapshotFunc with a clone function to capture their state.
+func Snapshot[E Interface, S ~[]E](xs S) S {
+	var snap S
+	CompactTo(&snap, xs)
+	return snap
+}
+
+// SnapshotFunc is like Snapshot, but stores clone(x) for each live element.
+func SnapshotFunc[E Interface, S ~[]E](xs S, clone func(E) E) S {
+	var snap S
+	for _, x := range xs {
+		if x.Alive() {
+			snap = append(snap, clone(x))
+		}
+	}
+	return snap
+}
+
+// Restore replaces the contents of *xs with those of snap, reusing the
+// capacity of *xs. snap is left untouched, but for pointer elements the
+// restored entities are shared with it; use RestoreFunc to restore from
+// the same snapshot more than once.
+func Restore[E any, S ~[]E](xs *S, snap S) {
+	Clear(xs)
+	*xs = append(*xs, snap...)
+}
+
+// RestoreFunc is like Restore, but stores clone(x) for each element of
+// snap, so that the snapshot stays intact for later rollbacks.
+func RestoreFunc[E any, S ~[]E](xs *S, snap S, clone func(E) E) {
+	Clear(xs)
+	for _, x := range snap {
+		*xs = append(*xs, clone(x))
+	}
+}
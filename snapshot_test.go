@@ -0,0 +1,30 @@
+package ei
+
+import "testing"
+
+func TestSnapshot(t *testing.T) {
+	clone := func(p *payload) *payload { c := *p; return &c }
+	xs := []*payload{{v: 1}, {v: 2}, {v: 3}}
+	xs[1].Kill()
+	snap := SnapshotFunc(xs, clone)
+	if len(snap) != 2 || snap[0].v != 1 || snap[1].v != 3 {
+		t.Fatalf("SnapshotFunc() = %v, want [1 3]", snap)
+	}
+	if shallow := Snapshot(xs); len(shallow) != 2 || shallow[0] != xs[0] {
+		t.Errorf("Snapshot() = %v, want the live elements of xs", shallow)
+	}
+
+	for range 2 {
+		xs[0].v = 10
+		xs[0].Kill()
+		RestoreFunc(&xs, snap, clone)
+		if len(xs) != 2 || xs[0].v != 1 || !xs[0].Alive() || xs[0] == snap[0] {
+			t.Fatalf("RestoreFunc() = %v, want fresh copies of [1 3]", xs)
+		}
+	}
+
+	Restore(&xs, snap)
+	if len(xs) != 2 || xs[0] != snap[0] || xs[1] != snap[1] {
+		t.Errorf("Restore() = %v, want the elements of snap", xs)
+	}
+}
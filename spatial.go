@@ -0,0 +1,174 @@
+package ei
+
+import (
+	"iter"
+	"math"
+)
+
+// Rect is an axis-aligned rectangle. Its edges are inclusive.
+type Rect struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+// Contains reports whether the point (x, y) lies in r.
+func (r Rect) Contains(x, y float64) bool {
+	return r.MinX <= x && x <= r.MaxX && r.MinY <= y && y <= r.MaxY
+}
+
+// Intersects reports whether r and s overlap.
+func (r Rect) Intersects(s Rect) bool {
+	return r.MinX <= s.MaxX && s.MinX <= r.MaxX && r.MinY <= s.MaxY && s.MinY <= r.MaxY
+}
+
+// SpatialHash holds positioned entities in a uniform grid of square cells,
+// so that neighbor queries only look at the cells around the query area.
+type SpatialHash[E interface {
+	Interface
+	comparable
+}] struct {
+	cellSize float64
+	items    []E
+	pos      map[E]spatialPos
+	cells    map[cellKey][]E
+}
+
+type cellKey struct{ x, y int }
+
+type spatialPos struct {
+	x, y float64
+	cell cellKey
+}
+
+// NewSpatialHash returns an empty grid with the given cell size, which
+// should be about the typical query size.
+func NewSpatialHash[E interface {
+	Interface
+	comparable
+}](cellSize float64) *SpatialHash[E] {
+	if !(cellSize > 0) {
+		panic("ei: NewSpatialHash: non-positive cell size")
+	}
+	return &SpatialHash[E]{
+		cellSize: cellSize,
+		pos:      map[E]spatialPos{},
+		cells:    map[cellKey][]E{},
+	}
+}
+
+func (h *SpatialHash[E]) cellOf(x, y float64) cellKey {
+	return cellKey{int(math.Floor(x / h.cellSize)), int(math.Floor(y / h.cellSize))}
+}
+
+// Insert adds e at (x, y). Inserting an entity that is already present
+// moves it like Move.
+func (h *SpatialHash[E]) Insert(e E, x, y float64) {
+	if _, ok := h.pos[e]; ok {
+		h.Move(e, x, y)
+		return
+	}
+	c := h.cellOf(x, y)
+	h.pos[e] = spatialPos{x, y, c}
+	h.items = append(h.items, e)
+	h.cells[c] = append(h.cells[c], e)
+}
+
+// Move updates the position of e. It does nothing if e is not present.
+func (h *SpatialHash[E]) Move(e E, x, y float64) {
+	p, ok := h.pos[e]
+	if !ok {
+		return
+	}
+	c := h.cellOf(x, y)
+	if c != p.cell {
+		h.removeFromCell(p.cell, e)
+		h.cells[c] = append(h.cells[c], e)
+	}
+	h.pos[e] = spatialPos{x, y, c}
+}
+
+func (h *SpatialHash[E]) removeFromCell(c cellKey, e E) {
+	bucket := h.cells[c]
+	for i, x := range bucket {
+		if x == e {
+			last := len(bucket) - 1
+			bucket[i] = bucket[last]
+			var zero E
+			bucket[last] = zero
+			bucket = bucket[:last]
+			break
+		}
+	}
+	if len(bucket) == 0 {
+		delete(h.cells, c)
+	} else {
+		h.cells[c] = bucket
+	}
+}
+
+// Position returns the position of e.
+func (h *SpatialHash[E]) Position(e E) (x, y float64, ok bool) {
+	p, ok := h.pos[e]
+	return p.x, p.y, ok
+}
+
+// Query returns an iterator over the live entities positioned in r, in no
+// particular order.
+func (h *SpatialHash[E]) Query(r Rect) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		lo, hi := h.cellOf(r.MinX, r.MinY), h.cellOf(r.MaxX, r.MaxY)
+		for cy := lo.y; cy <= hi.y; cy++ {
+			for cx := lo.x; cx <= hi.x; cx++ {
+				for _, e := range h.cells[cellKey{cx, cy}] {
+					p := h.pos[e]
+					if r.Contains(p.x, p.y) && e.Alive() && !yield(e) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// All returns an iterator over the live entities in insertion order.
+func (h *SpatialHash[E]) All() iter.Seq[E] { return Filter(h.items) }
+
+// Len returns the number of entities, including dead ones not yet swept.
+func (h *SpatialHash[E]) Len() int { return len(h.items) }
+
+// Sweep removes dead entities from both the entity list and the grid, and
+// returns the number removed.
+func (h *SpatialHash[E]) Sweep() int {
+	dirty := map[cellKey]struct{}{}
+	j := 0
+	for _, e := range h.items {
+		if e.Alive() {
+			h.items[j] = e
+			j++
+			continue
+		}
+		dirty[h.pos[e].cell] = struct{}{}
+		delete(h.pos, e)
+		dispose(e)
+	}
+	n := len(h.items) - j
+	clear(h.items[j:])
+	h.items = h.items[:j]
+
+	for c := range dirty {
+		bucket := h.cells[c]
+		k := 0
+		for _, e := range bucket {
+			if _, ok := h.pos[e]; ok {
+				bucket[k] = e
+				k++
+			}
+		}
+		clear(bucket[k:])
+		if k == 0 {
+			delete(h.cells, c)
+		} else {
+			h.cells[c] = bucket[:k]
+		}
+	}
+	return n
+}
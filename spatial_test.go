@@ -0,0 +1,46 @@
+package ei
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSpatialHash(t *testing.T) {
+	h := NewSpatialHash[*disposed](10)
+	a, b, c := &disposed{}, &disposed{}, &disposed{}
+	h.Insert(a, 1, 1)
+	h.Insert(b, 15, 5)
+	h.Insert(c, -5, -5)
+	query := func(r Rect) []*disposed {
+		got := slices.Collect(h.Query(r))
+		slices.SortFunc(got, func(x, y *disposed) int {
+			return slices.Index([]*disposed{a, b, c}, x) - slices.Index([]*disposed{a, b, c}, y)
+		})
+		return got
+	}
+	if got := query(Rect{0, 0, 20, 20}); !slices.Equal(got, []*disposed{a, b}) {
+		t.Errorf("Query() = %v, want [a b]", got)
+	}
+
+	h.Move(a, -1, -1)
+	if got := query(Rect{-10, -10, 0, 0}); !slices.Equal(got, []*disposed{a, c}) {
+		t.Errorf("Query() after Move = %v, want [a c]", got)
+	}
+	if x, y, ok := h.Position(a); !ok || x != -1 || y != -1 {
+		t.Errorf("Position(a) = %v, %v, %v", x, y, ok)
+	}
+
+	c.Kill()
+	if got := query(Rect{-10, -10, 0, 0}); !slices.Equal(got, []*disposed{a}) {
+		t.Errorf("Query() with a dead entity = %v, want [a]", got)
+	}
+	if n := h.Sweep(); n != 1 || c.n != 1 || h.Len() != 2 {
+		t.Errorf("Sweep() = %d, c disposed %d times, Len() = %d", n, c.n, h.Len())
+	}
+	if len(h.cells[h.cellOf(-5, -5)]) != 1 {
+		t.Error("swept entity left in its cell")
+	}
+	if _, _, ok := h.Position(c); ok {
+		t.Error("Position() found a swept entity")
+	}
+}
@@ -0,0 +1,34 @@
+package ei
+
+import "slices"
+
+// Spawn appends v and returns a pointer to the stored element.
+//
+// The pointer points into the backing array of *xs, so it is invalidated by
+// any later append that reallocates and by any sweep that moves elements.
+// Do not keep it beyond the current statement block.
+func Spawn[E any, S ~[]E](xs *S, v E) *E {
+	*xs = append(*xs, v)
+	return &(*xs)[len(*xs)-1]
+}
+
+// SpawnZero is like Spawn, but appends a zero element. The same
+// invalidation rules apply to the returned pointer.
+func SpawnZero[E any, S ~[]E](xs *S) *E {
+	var zero E
+	return Spawn(xs, zero)
+}
+
+// Reserve grows the capacity of *xs, if necessary, so that n more elements
+// can be appended without another allocation.
+func Reserve[E any, S ~[]E](xs *S, n int) {
+	*xs = slices.Grow(*xs, n)
+}
+
+// AddAll appends items and returns the half-open range [start, end) of
+// indices they occupy.
+func AddAll[E any, S ~[]E](xs *S, items ...E) (start, end int) {
+	start = len(*xs)
+	*xs = append(*xs, items...)
+	return start, len(*xs)
+}
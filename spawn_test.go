@@ -0,0 +1,64 @@
+package ei
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSpawn(t *testing.T) {
+	var xs []payload
+	p := Spawn(&xs, payload{v: 1})
+	p.v = 2
+	if xs[0].v != 2 {
+		t.Errorf("xs[0].v = %d, want 2: Spawn must return a pointer into the slice", xs[0].v)
+	}
+	z := SpawnZero(&xs)
+	if z != &xs[1] || z.v != 0 || !z.Alive() {
+		t.Errorf("SpawnZero() = %+v, want a live zero element at xs[1]", z)
+	}
+}
+
+func TestReserve(t *testing.T) {
+	var xs []payload
+	Reserve(&xs, 100)
+	allocs := testing.AllocsPerRun(10, func() {
+		xs = xs[:0]
+		for range 100 {
+			SpawnZero(&xs)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("%v allocations after Reserve, want 0", allocs)
+	}
+}
+
+func BenchmarkSpawn(b *testing.B) {
+	b.Run("Append", func(b *testing.B) {
+		for range b.N {
+			var xs []payload
+			for range 1000 {
+				SpawnZero(&xs)
+			}
+		}
+	})
+	b.Run("Reserve", func(b *testing.B) {
+		for range b.N {
+			var xs []payload
+			Reserve(&xs, 1000)
+			for range 1000 {
+				SpawnZero(&xs)
+			}
+		}
+	})
+}
+
+func TestAddAll(t *testing.T) {
+	xs := []int{1, 2}
+	start, end := AddAll(&xs, 3, 4, 5)
+	if start != 2 || end != 5 || !slices.Equal(xs[start:end], []int{3, 4, 5}) {
+		t.Errorf("AddAll() = %d, %d, xs = %v", start, end, xs)
+	}
+	if start, end := AddAll(&xs); start != 5 || end != 5 {
+		t.Errorf("AddAll() without items = %d, %d, want 5, 5", start, end)
+	}
+}
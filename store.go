@@ -0,0 +1,53 @@
+package ei
+
+// Store holds a slice of entities along with parallel component slices that
+// are compacted in lock-step with it. The i-th element of every component
+// slice belongs to Entities[i].
+type Store[E Interface] struct {
+	Entities []E
+	cols     []column
+}
+
+type column interface {
+	len() int
+	move(dst, src int)
+	truncate(n int)
+}
+
+type sliceColumn[T any] struct{ s *[]T }
+
+func (c sliceColumn[T]) len() int          { return len(*c.s) }
+func (c sliceColumn[T]) move(dst, src int) { (*c.s)[dst] = (*c.s)[src] }
+
+func (c sliceColumn[T]) truncate(n int) {
+	clear((*c.s)[n:])
+	*c.s = (*c.s)[:n]
+}
+
+// AddColumn registers a component slice with the store. The caller keeps
+// appending to *col alongside s.Entities; Sweep only removes from it.
+func AddColumn[E Interface, T any](s *Store[E], col *[]T) {
+	s.cols = append(s.cols, sliceColumn[T]{col})
+}
+
+// Sweep removes dead entities and the corresponding elements of every
+// component slice, keeping them aligned and in order. It panics if a
+// component slice has a different length than Entities. It returns the
+// number of removed entities.
+func (s *Store[E]) Sweep() int {
+	n := len(s.Entities)
+	for _, c := range s.cols {
+		if c.len() != len(s.Entities) {
+			panic("ei: Store.Sweep: component length mismatch")
+		}
+	}
+	SweepMoved(&s.Entities, func(oldIndex, newIndex int, _ E) {
+		for _, c := range s.cols {
+			c.move(newIndex, oldIndex)
+		}
+	})
+	for _, c := range s.cols {
+		c.truncate(len(s.Entities))
+	}
+	return n - len(s.Entities)
+}
@@ -0,0 +1,43 @@
+package ei
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestStore(t *testing.T) {
+	var s Store[*Entity]
+	var pos []int
+	var name []string
+	AddColumn(&s, &pos)
+	AddColumn(&s, &name)
+	for i, n := range []string{"a", "b", "c", "d", "e"} {
+		s.Entities = append(s.Entities, &Entity{})
+		pos = append(pos, i)
+		name = append(name, n)
+	}
+	s.Entities[0].Kill()
+	s.Entities[3].Kill()
+	if n := s.Sweep(); n != 2 {
+		t.Errorf("Sweep() = %d, want 2", n)
+	}
+	if len(s.Entities) != 3 || !slices.Equal(pos, []int{1, 2, 4}) || !slices.Equal(name, []string{"b", "c", "e"}) {
+		t.Errorf("after Sweep: %d entities, pos = %v, name = %v", len(s.Entities), pos, name)
+	}
+	if full := name[:5]; full[3] != "" || full[4] != "" {
+		t.Errorf("tail of name not zeroed: %q", full)
+	}
+}
+
+func TestStoreMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("no panic on a component length mismatch")
+		}
+	}()
+	var s Store[*Entity]
+	var pos []int
+	AddColumn(&s, &pos)
+	s.Entities = newEntities(1)
+	s.Sweep()
+}
@@ -0,0 +1,61 @@
+package ei
+
+import "sync"
+
+// SyncList is like List, but safe for use by multiple goroutines. The
+// liveness of the entities must be synchronized as well, as with
+// EntityAtomic, since Kill may run concurrently with Range.
+type SyncList[E Interface] struct {
+	mu    sync.RWMutex
+	items []E
+}
+
+// Add appends e.
+func (l *SyncList[E]) Add(e E) {
+	l.mu.Lock()
+	l.items = append(l.items, e)
+	l.mu.Unlock()
+}
+
+// Len returns the number of entities, including dead ones not yet swept.
+func (l *SyncList[E]) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return len(l.items)
+}
+
+// Kill kills the i-th entity. It stays in the list until the next Sweep.
+func (l *SyncList[E]) Kill(i int) {
+	l.mu.RLock()
+	e := l.items[i]
+	l.mu.RUnlock()
+	e.Kill()
+}
+
+// Range calls yield for each live entity and its index until yield returns
+// false. The list is read-locked meanwhile, so yield must not call any
+// method of the list, not even Kill, which could deadlock against a waiting
+// Add or Sweep. Kill the entity passed to yield directly instead:
+//
+//	for _, e := range l.Range {
+//		if e.HP <= 0 {
+//			e.Kill()
+//		}
+//	}
+func (l *SyncList[E]) Range(yield func(int, E) bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for i, x := range l.items {
+		if x.Alive() && !yield(i, x) {
+			return
+		}
+	}
+}
+
+// Sweep removes dead entities, keeping the rest in order, and returns the
+// number removed.
+func (l *SyncList[E]) Sweep() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return SweepN(&l.items)
+}
@@ -0,0 +1,100 @@
+package ei
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+type atomicEntity struct {
+	EntityAtomic
+	n int
+}
+
+func TestSyncListConcurrent(t *testing.T) {
+	var l SyncList[*atomicEntity]
+	var wg sync.WaitGroup
+	for w := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 200 {
+				e := &atomicEntity{n: w*1000 + i}
+				l.Add(e)
+				if i%3 == 0 {
+					// Indices shift with concurrent sweeps, so kill
+					// through the entity.
+					e.Kill()
+				}
+				for _, e := range l.Range {
+					if e.n%5 == 0 {
+						e.Kill()
+					}
+				}
+				if i%10 == 0 {
+					l.Sweep()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	l.Sweep()
+	for _, e := range l.Range {
+		if !e.Alive() {
+			t.Fatalf("dead entity %d after Sweep", e.n)
+		}
+	}
+}
+
+func TestSyncListKill(t *testing.T) {
+	var l SyncList[*atomicEntity]
+	for i := range 3 {
+		l.Add(&atomicEntity{n: i})
+	}
+	l.Kill(1)
+	var got []int
+	for _, e := range l.Range {
+		got = append(got, e.n)
+	}
+	if len(got) != 2 || got[0] != 0 || got[1] != 2 {
+		t.Errorf("Range after Kill(1) = %v, want [0 2]", got)
+	}
+	if n := l.Sweep(); n != 1 {
+		t.Errorf("Sweep() = %d, want 1", n)
+	}
+}
+
+func TestSyncListKillInRange(t *testing.T) {
+	var l SyncList[*atomicEntity]
+	for i := range 10 {
+		l.Add(&atomicEntity{n: i})
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, e := range l.Range {
+			// Let a writer queue up on the lock while we hold it.
+			time.Sleep(time.Millisecond)
+			e.Kill()
+		}
+	}()
+	added := make(chan struct{})
+	go func() {
+		defer close(added)
+		time.Sleep(2 * time.Millisecond)
+		l.Add(&atomicEntity{})
+	}()
+	for _, c := range []chan struct{}{done, added} {
+		select {
+		case <-c:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Range deadlocked")
+		}
+	}
+	if n := l.Sweep(); n != 10 {
+		t.Errorf("Sweep() = %d, want 10", n)
+	}
+	if n := l.Len(); n != 1 {
+		t.Errorf("Len() = %d, want 1", n)
+	}
+}
@@ -0,0 +1,42 @@
+package ei
+
+import "sync"
+
+// SweepSyncMap deletes the entries of m whose values implement Interface and
+// are dead, and returns the number deleted. Other values are left alone. An
+// entry is only deleted if it still holds the dead value, so a concurrent
+// Store of a new value for the same key is not lost; the values must
+// therefore be comparable, as pointers are.
+func SweepSyncMap(m *sync.Map) int {
+	n := 0
+	m.Range(func(k, v any) bool {
+		if e, ok := v.(Interface); ok && !e.Alive() && m.CompareAndDelete(k, v) {
+			dispose(v)
+			n++
+		}
+		return true
+	})
+	return n
+}
+
+// SweepEachSyncMap is like SweepSyncMap for a map whose keys are K and values
+// are V, but also calls pred for each surviving entry. Entries of other
+// types are ignored.
+func SweepEachSyncMap[K comparable, V Interface](m *sync.Map, pred func(K, V)) int {
+	n := 0
+	m.Range(func(k, v any) bool {
+		key, ok1 := k.(K)
+		val, ok2 := v.(V)
+		if !ok1 || !ok2 {
+			return true
+		}
+		if val.Alive() {
+			pred(key, val)
+		} else if m.CompareAndDelete(k, v) {
+			dispose(v)
+			n++
+		}
+		return true
+	})
+	return n
+}
@@ -0,0 +1,60 @@
+package ei
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSweepSyncMap(t *testing.T) {
+	var m sync.Map
+	dead := &atomicEntity{}
+	dead.Kill()
+	m.Store(1, &atomicEntity{n: 1})
+	m.Store(2, dead)
+	m.Store(3, "not an entity")
+	if n := SweepSyncMap(&m); n != 1 {
+		t.Errorf("SweepSyncMap() = %d, want 1", n)
+	}
+	if _, ok := m.Load(2); ok {
+		t.Error("dead entry not deleted")
+	}
+	if _, ok := m.Load(3); !ok {
+		t.Error("non-entity entry deleted")
+	}
+
+	m.Store(4, dead)
+	var keys []int
+	if n := SweepEachSyncMap(&m, func(k int, _ *atomicEntity) { keys = append(keys, k) }); n != 1 {
+		t.Errorf("SweepEachSyncMap() = %d, want 1", n)
+	}
+	if len(keys) != 1 || keys[0] != 1 {
+		t.Errorf("pred called with keys %v, want [1]", keys)
+	}
+}
+
+func TestSweepSyncMapConcurrent(t *testing.T) {
+	var m sync.Map
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := range 1000 {
+			e := &atomicEntity{n: i}
+			m.Store(i%10, e)
+			e.Kill()
+			m.Store(i%10, &atomicEntity{n: i}) // must survive the sweep
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			SweepSyncMap(&m)
+		}
+	}()
+	wg.Wait()
+	for k := range 10 {
+		if v, ok := m.Load(k); !ok || !v.(*atomicEntity).Alive() {
+			t.Errorf("entry %d lost: %v, %v", k, v, ok)
+		}
+	}
+}
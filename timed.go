@@ -0,0 +1,60 @@
+package ei
+
+// TimedEntity is an Entity that kills itself after a number of ticks.
+type TimedEntity struct {
+	Entity
+	life int
+}
+
+// NewTimed returns an alive entity that dies on the given tick. Like
+// NewEntity, it calls OnSpawn.
+func NewTimed(frames int) TimedEntity {
+	return TimedEntity{Entity: NewEntity(), life: frames}
+}
+
+// Tick decrements the remaining lifetime and kills the entity when it
+// reaches zero. It does nothing on a dead entity.
+func (e *TimedEntity) Tick() {
+	if e.dead {
+		return
+	}
+	e.life--
+	if e.life <= 0 {
+		e.Kill()
+	}
+}
+
+// Remaining returns the number of ticks left before the entity dies, or 0
+// if it is dead.
+func (e *TimedEntity) Remaining() int {
+	if e.dead || e.life < 0 {
+		return 0
+	}
+	return e.life
+}
+
+// Extend adds frames to the remaining lifetime. It does not revive a dead
+// entity.
+func (e *TimedEntity) Extend(frames int) { e.life += frames }
+
+// SetLifetime sets the remaining lifetime. It does not revive a dead entity.
+func (e *TimedEntity) SetLifetime(frames int) { e.life = frames }
+
+// TickSweep calls Tick on every element, then removes dead elements.
+func TickSweep[E interface {
+	Interface
+	Tick()
+}, S ~[]E](xs *S) {
+	if xs == nil {
+		return
+	}
+	TickAll(*xs)
+	Sweep(xs)
+}
+
+// TickAll calls Tick on every element without removing anything.
+func TickAll[E interface{ Tick() }, S ~[]E](xs S) {
+	for _, x := range xs {
+		x.Tick()
+	}
+}
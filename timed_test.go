@@ -0,0 +1,54 @@
+package ei
+
+import "testing"
+
+func TestTickSweep(t *testing.T) {
+	a, b := NewTimed(3), NewTimed(5)
+	xs := []*TimedEntity{&a, &b}
+	for tick := 1; tick <= 3; tick++ {
+		if !a.Alive() {
+			t.Fatalf("died before tick %d", tick)
+		}
+		TickSweep(&xs)
+	}
+	if a.Alive() {
+		t.Error("alive after the third tick")
+	}
+	if len(xs) != 1 || xs[0] != &b {
+		t.Errorf("xs = %v, want [b]", xs)
+	}
+}
+
+func TestRemaining(t *testing.T) {
+	e := NewTimed(2)
+	e.Tick()
+	if r := e.Remaining(); r != 1 {
+		t.Errorf("Remaining() = %d, want 1", r)
+	}
+	e.Extend(2)
+	if r := e.Remaining(); r != 3 {
+		t.Errorf("Remaining() after Extend = %d, want 3", r)
+	}
+	e.SetLifetime(-5)
+	if r := e.Remaining(); r != 0 {
+		t.Errorf("Remaining() with a negative lifetime = %d, want 0", r)
+	}
+	e.SetLifetime(1)
+	e.Tick()
+	if e.Alive() || e.Remaining() != 0 {
+		t.Errorf("after the last tick: alive = %v, Remaining() = %d", e.Alive(), e.Remaining())
+	}
+	e.Extend(10)
+	if e.Alive() || e.Remaining() != 0 {
+		t.Error("Extend revived a dead entity")
+	}
+}
+
+func TestTickAll(t *testing.T) {
+	a, b := NewTimed(1), NewTimed(2)
+	xs := []*TimedEntity{&a, &b}
+	TickAll(xs)
+	if a.Alive() || !b.Alive() || len(xs) != 2 {
+		t.Errorf("after TickAll: a alive = %v, b alive = %v, len = %d", a.Alive(), b.Alive(), len(xs))
+	}
+}
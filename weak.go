@@ -0,0 +1,78 @@
+package ei
+
+import "weak"
+
+// WeakRef is like Ref, but does not keep the entity reachable, so a swept
+// entity can be collected even while references to it remain.
+type WeakRef[E any, P interface {
+	*E
+	Interface
+}] struct {
+	p weak.Pointer[E]
+}
+
+// NewWeakRef returns a weak reference to p.
+func NewWeakRef[E any, P interface {
+	*E
+	Interface
+}](p P) WeakRef[E, P] {
+	return WeakRef[E, P]{weak.Make((*E)(p))}
+}
+
+// Get returns the entity and reports whether it is still alive. It reports
+// false once the entity is dead or has been collected.
+func (r WeakRef[E, P]) Get() (P, bool) {
+	p := P(r.p.Value())
+	if p == nil || !p.Alive() {
+		return nil, false
+	}
+	return p, true
+}
+
+// WeakCache maps entities to values without keeping the entities reachable.
+// Entries of dead or collected entities are no longer returned by Get, and
+// are removed by Sweep.
+type WeakCache[E any, P interface {
+	*E
+	Interface
+}, V any] struct {
+	m map[weak.Pointer[E]]V
+}
+
+// Set associates v with p.
+func (c *WeakCache[E, P, V]) Set(p P, v V) {
+	if c.m == nil {
+		c.m = map[weak.Pointer[E]]V{}
+	}
+	c.m[weak.Make((*E)(p))] = v
+}
+
+// Get returns the value associated with p and reports whether it is present
+// and p is alive.
+func (c *WeakCache[E, P, V]) Get(p P) (V, bool) {
+	v, ok := c.m[weak.Make((*E)(p))]
+	if !ok || !p.Alive() {
+		var zero V
+		return zero, false
+	}
+	return v, true
+}
+
+// Delete removes the value associated with p.
+func (c *WeakCache[E, P, V]) Delete(p P) { delete(c.m, weak.Make((*E)(p))) }
+
+// Len returns the number of entries, including those of dead or collected
+// entities not yet swept.
+func (c *WeakCache[E, P, V]) Len() int { return len(c.m) }
+
+// Sweep removes the entries of dead or collected entities and returns the
+// number removed.
+func (c *WeakCache[E, P, V]) Sweep() int {
+	n := len(c.m)
+	for k := range c.m {
+		if p := P(k.Value()); p == nil || !p.Alive() {
+			delete(c.m, k)
+		}
+	}
+	return n - len(c.m)
+}
@@ -0,0 +1,48 @@
+package ei
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestWeakRef(t *testing.T) {
+	e := &payload{v: 1}
+	r := NewWeakRef(e)
+	if got, ok := r.Get(); !ok || got != e {
+		t.Errorf("Get() = %p, %v, want e, true", got, ok)
+	}
+	e.Kill()
+	if _, ok := r.Get(); ok {
+		t.Error("Get() of a dead entity reported true")
+	}
+
+	r = NewWeakRef(&payload{})
+	runtime.GC()
+	if _, ok := r.Get(); ok {
+		t.Error("Get() of a collected entity reported true")
+	}
+}
+
+func TestWeakCache(t *testing.T) {
+	var c WeakCache[payload, *payload, string]
+	a, b := &payload{}, &payload{}
+	c.Set(a, "a")
+	c.Set(b, "b")
+	c.Set(&payload{}, "collected")
+	runtime.GC()
+	if v, ok := c.Get(a); !ok || v != "a" {
+		t.Errorf("Get(a) = %q, %v, want a, true", v, ok)
+	}
+	b.Kill()
+	if _, ok := c.Get(b); ok {
+		t.Error("Get() of a dead entity reported true")
+	}
+	if n := c.Sweep(); n != 2 || c.Len() != 1 {
+		t.Errorf("Sweep() = %d, Len() = %d, want 2, 1", n, c.Len())
+	}
+	c.Delete(a)
+	if c.Len() != 0 {
+		t.Errorf("Len() after Delete = %d, want 0", c.Len())
+	}
+	runtime.KeepAlive(b)
+}
@@ -0,0 +1,90 @@
+package ei
+
+// Sweeper is a collection that can remove its dead entities. Sweep returns
+// the number removed. The containers in this package implement it, and
+// Slice and Map adapt plain slices and maps.
+type Sweeper interface {
+	Sweep() int
+}
+
+type sliceSweeper[E Interface, S ~[]E] struct{ xs *S }
+
+func (s sliceSweeper[E, S]) Sweep() int { return SweepN(s.xs) }
+
+// Slice returns a Sweeper that sweeps *xs with SweepN.
+func Slice[E Interface, S ~[]E](xs *S) Sweeper { return sliceSweeper[E, S]{xs} }
+
+type mapSweeper[K comparable, V Interface, M ~map[K]V] struct{ m M }
+
+func (s mapSweeper[K, V, M]) Sweep() int { return SweepMapN(s.m) }
+
+// Map returns a Sweeper that sweeps m with SweepMapN.
+func Map[K comparable, V Interface, M ~map[K]V](m M) Sweeper { return mapSweeper[K, V, M]{m} }
+
+// SweepAll sweeps each collection in order and returns the total number of
+// removed entities. Use a World to keep per-collection statistics.
+//
+//	ei.SweepAll(ei.Slice(&enemies), ei.Slice(&bullets), ei.Map(items))
+func SweepAll(ss ...Sweeper) int {
+	total := 0
+	for _, s := range ss {
+		total += s.Sweep()
+	}
+	return total
+}
+
+// SweepStats holds per-collection statistics of a World.
+type SweepStats struct {
+	Sweeps      int // number of sweeps
+	Removed     int // total number of removed entities
+	LastRemoved int // number removed by the last sweep
+}
+
+// World sweeps every registered collection at once.
+//
+//	var w ei.World
+//	w.Register("enemies", ei.Slice(&enemies))
+//	w.Register("items", ei.Map(items))
+//	...
+//	w.Sweep() // once per frame
+type World struct {
+	entries []worldEntry
+}
+
+type worldEntry struct {
+	name  string
+	s     Sweeper
+	stats SweepStats
+}
+
+// Register adds a collection under the given name. Names are only used to
+// look up statistics and need not be unique.
+func (w *World) Register(name string, s Sweeper) {
+	w.entries = append(w.entries, worldEntry{name: name, s: s})
+}
+
+// Sweep sweeps every collection in registration order and returns the
+// total number of removed entities.
+func (w *World) Sweep() int {
+	total := 0
+	for i := range w.entries {
+		e := &w.entries[i]
+		n := e.s.Sweep()
+		e.stats.Sweeps++
+		e.stats.Removed += n
+		e.stats.LastRemoved = n
+		total += n
+	}
+	return total
+}
+
+// Stats returns the statistics of the first collection registered under
+// name.
+func (w *World) Stats(name string) (SweepStats, bool) {
+	for _, e := range w.entries {
+		if e.name == name {
+			return e.stats, true
+		}
+	}
+	return SweepStats{}, false
+}
@@ -0,0 +1,50 @@
+package ei
+
+import "testing"
+
+// The containers of the package are Sweepers.
+var (
+	_ Sweeper = (*Arena[*Entity])(nil)
+	_ Sweeper = (*Buffer[*Entity])(nil)
+	_ Sweeper = (*IndexMap[*IdentifiedEntity])(nil)
+	_ Sweeper = (*List[*Entity])(nil)
+	_ Sweeper = (*Ring[*Entity])(nil)
+	_ Sweeper = (*Store[*Entity])(nil)
+)
+
+func TestWorld(t *testing.T) {
+	xs := mixed()
+	m := map[int]*Entity{1: {dead: true}}
+	var w World
+	w.Register("xs", Slice(&xs))
+	w.Register("m", Map(m))
+	if n := w.Sweep(); n != 3 {
+		t.Errorf("Sweep() = %d, want 3", n)
+	}
+	xs[0].Kill()
+	w.Sweep()
+	if s, ok := w.Stats("xs"); !ok || s != (SweepStats{Sweeps: 2, Removed: 3, LastRemoved: 1}) {
+		t.Errorf("Stats(xs) = %+v, %v", s, ok)
+	}
+	if s, _ := w.Stats("m"); s != (SweepStats{Sweeps: 2, Removed: 1, LastRemoved: 0}) {
+		t.Errorf("Stats(m) = %+v", s)
+	}
+	if _, ok := w.Stats("none"); ok {
+		t.Error("Stats() found an unregistered name")
+	}
+}
+
+func TestSweepAll(t *testing.T) {
+	xs := mixed()
+	var ys []*Entity
+	m := map[string]*Entity{"a": {dead: true}, "b": {}}
+	if n := SweepAll(Slice(&xs), Slice(&ys), Map(m)); n != 3 {
+		t.Errorf("SweepAll() = %d, want 3", n)
+	}
+	if len(xs) != 3 || len(m) != 1 {
+		t.Errorf("len(xs) = %d, len(m) = %d, want 3, 1", len(xs), len(m))
+	}
+	if n := SweepAll(); n != 0 {
+		t.Errorf("SweepAll() without collections = %d, want 0", n)
+	}
+}